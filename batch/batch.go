@@ -0,0 +1,105 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package batch adapts any parse.Parser to return multiple tokens at a time, see parse.NextNAble.
+package batch
+
+import (
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/pool"
+)
+
+// Parser is a parser that can also return multiple tokens at a time.
+type Parser interface {
+	parse.Parser
+	parse.NextNAble
+}
+
+type parser struct {
+	p      parse.Parser
+	native parse.NextNAble
+	pool   pool.Pool
+	// chunk is the buffer that values are currently copied into.
+	chunk []byte
+}
+
+// minChunk is the minimum size of the buffers that values are copied into.
+const minChunk = 4096
+
+// NewParser returns a parser that implements NextN over p.
+// If p implements parse.NextNAble, then NextN is passed through,
+// otherwise NextN calls Next and Token on p and copies the values into buffers from a pool,
+// which are freed on the next call to NextN.
+// Next, Skip and Token are passed through to p.
+func NewParser(p parse.Parser, opts ...Option) Parser {
+	b := &parser{
+		p:    p,
+		pool: pool.New(),
+	}
+	b.native, _ = p.(parse.NextNAble)
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func (b *parser) NextN(events []parse.Event) (int, error) {
+	if b.native != nil {
+		return b.native.NextN(events)
+	}
+	b.pool.FreeAll()
+	b.chunk = nil
+	for n := range events {
+		hint, err := b.p.Next()
+		if err != nil {
+			return n, err
+		}
+		events[n] = parse.Event{Hint: hint}
+		switch hint {
+		case parse.FieldHint, parse.ValueHint:
+			kind, value, err := b.p.Token()
+			if err != nil {
+				return n, err
+			}
+			events[n].Kind = kind
+			events[n].Value = b.copy(value)
+		}
+	}
+	return len(events), nil
+}
+
+// copy copies the value into the current chunk, allocating a new chunk from the pool if the value does not fit.
+func (b *parser) copy(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	if len(value) > cap(b.chunk)-len(b.chunk) {
+		b.chunk = b.pool.Alloc(max(minChunk, len(value)))[:0]
+	}
+	start := len(b.chunk)
+	b.chunk = append(b.chunk, value...)
+	return b.chunk[start:len(b.chunk):len(b.chunk)]
+}
+
+func (b *parser) Next() (parse.Hint, error) {
+	return b.p.Next()
+}
+
+func (b *parser) Skip() error {
+	return b.p.Skip()
+}
+
+func (b *parser) Token() (parse.Kind, []byte, error) {
+	return b.p.Token()
+}
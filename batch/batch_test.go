@@ -0,0 +1,157 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package batch
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"katydid.org.za/go/parser-go/internal/replaytest"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/parse/debug"
+	"katydid.org.za/go/parser-go/replay"
+)
+
+// encode returns a token stream for an array of n objects, each with a few fields.
+func encode(b testing.TB, n int) []byte {
+	b.Helper()
+	return replaytest.Encode(b, func(enc *replaytest.Encoder) {
+		enc.Enter(jsonschema.JSONSchemaTypeArray)
+		for i := 0; i < n; i++ {
+			enc.Enter(jsonschema.JSONSchemaTypeObject)
+			enc.Field(parse.StringKind, []byte("id"))
+			enc.Value(parse.Int64Kind, replaytest.Int64(int64(i)))
+			enc.Field(parse.StringKind, []byte("name"))
+			enc.Value(parse.StringKind, []byte(fmt.Sprintf("name %d", i)))
+			enc.Field(parse.StringKind, []byte("ok"))
+			enc.Value(parse.TrueKind, nil)
+			enc.Leave()
+		}
+		enc.Leave()
+	})
+}
+
+// noNextN hides the NextN method of the parser.
+type noNextN struct {
+	replay.Parser
+}
+
+func newReplay(buf []byte) replay.Parser {
+	p := replay.NewParser()
+	p.Init(buf)
+	return p
+}
+
+// walk returns the events of the parser, by calling Next and Token.
+func walk(t *testing.T, p parse.Parser) []parse.Event {
+	var events []parse.Event
+	for {
+		hint, err := p.Next()
+		if err == io.EOF {
+			return events
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		kind, value, err := p.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, parse.Event{Hint: hint, Kind: kind, Value: append([]byte(nil), value...)})
+	}
+}
+
+// walkN returns the events of the parser, by calling NextN.
+func walkN(t *testing.T, p parse.NextNAble, size int) []parse.Event {
+	var events []parse.Event
+	batch := make([]parse.Event, size)
+	for {
+		n, err := p.NextN(batch)
+		for _, e := range batch[:n] {
+			events = append(events, parse.Event{Hint: e.Hint, Kind: e.Kind, Value: append([]byte(nil), e.Value...)})
+		}
+		if err == io.EOF {
+			return events
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func equal(want, got []parse.Event) bool {
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if want[i].Hint != got[i].Hint || want[i].Kind != got[i].Kind || !bytes.Equal(want[i].Value, got[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNextN(t *testing.T) {
+	buf := encode(t, 100)
+	want := walk(t, newReplay(buf))
+	for _, size := range []int{1, 3, 1000} {
+		if got := walkN(t, NewParser(noNextN{newReplay(buf)}), size); !equal(want, got) {
+			t.Fatalf("adapter with batch size %d: want %v, but got %v", size, want, got)
+		}
+		if got := walkN(t, NewParser(newReplay(buf)), size); !equal(want, got) {
+			t.Fatalf("native with batch size %d: want %v, but got %v", size, want, got)
+		}
+	}
+}
+
+func BenchmarkWalk(b *testing.B) {
+	buf := encode(b, 1000)
+	p := replay.NewParser()
+	for b.Loop() {
+		p.Init(buf)
+		if err := debug.Walk(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkNextN(b *testing.B, p parse.ParserWithInit, n parse.NextNAble) {
+	buf := encode(b, 1000)
+	events := make([]parse.Event, 64)
+	for b.Loop() {
+		p.Init(buf)
+		for {
+			if _, err := n.NextN(events); err != nil {
+				if err == io.EOF {
+					break
+				}
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkNextNAdapter(b *testing.B) {
+	p := noNextN{replay.NewParser()}
+	benchmarkNextN(b, p, NewParser(p))
+}
+
+func BenchmarkNextNNative(b *testing.B) {
+	p := replay.NewParser()
+	benchmarkNextN(b, p, NewParser(p))
+}
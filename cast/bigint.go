@@ -0,0 +1,37 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package cast
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ToBigInt parses the decimal text of a BigIntKind token, with an optional sign, for example `-18446744073709551617`.
+func ToBigInt(bs []byte) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(string(bs), 10)
+	if !ok {
+		return nil, fmt.Errorf("cannot cast %q to a big integer", bs)
+	}
+	return i, nil
+}
+
+// FromBigInt writes the decimal text of the integer into a slice that is allocated using alloc.
+func FromBigInt(i *big.Int, alloc func(size int) []byte) []byte {
+	text := i.Append(nil, 10)
+	bs := alloc(len(text))
+	copy(bs, text)
+	return bs
+}
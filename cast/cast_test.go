@@ -188,3 +188,20 @@ func TestCastString(t *testing.T) {
 		t.Fatalf("want %s got %s", want, got)
 	}
 }
+
+func TestCastFromAllocates(t *testing.T) {
+	allocs := 0
+	alloc := func(size int) []byte {
+		allocs++
+		return make([]byte, size)
+	}
+	FromInt64(1, alloc)
+	FromInt32(1, alloc)
+	FromUint64(1, alloc)
+	FromUint32(1, alloc)
+	FromFloat64(1, alloc)
+	FromFloat32(1, alloc)
+	if allocs != 6 {
+		t.Fatalf("want every From function to use the allocator, but got %d allocations", allocs)
+	}
+}
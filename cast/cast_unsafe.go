@@ -18,7 +18,6 @@ package cast
 
 import (
 	"math"
-	"reflect"
 	"unsafe"
 )
 
@@ -26,52 +25,44 @@ func ToInt64(bs []byte) int64 {
 	return *(*int64)(unsafe.Pointer(&bs[0]))
 }
 
-// FromInt64 is very unsafe, you have make sure to keep the int64 in a value that won't be freed, until you are doing using this slice.
-func FromInt64(i int64, _alloc func(size int) []byte) []byte {
-	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
-		Len:  8,
-		Cap:  8,
-		Data: uintptr(unsafe.Pointer(&i)),
-	}))
+// FromInt64 writes the int64 into a slice that is allocated using alloc.
+func FromInt64(i int64, alloc func(size int) []byte) []byte {
+	bs := alloc(8)
+	*(*int64)(unsafe.Pointer(&bs[0])) = i
+	return bs
 }
 
 func ToInt32(bs []byte) int32 {
 	return *(*int32)(unsafe.Pointer(&bs[0]))
 }
 
-// FromInt32 is very unsafe, you have make sure to keep the int32 in a value that won't be freed, until you are doing using this slice.
-func FromInt32(i int32, _alloc func(size int) []byte) []byte {
-	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
-		Len:  4,
-		Cap:  4,
-		Data: uintptr(unsafe.Pointer(&i)),
-	}))
+// FromInt32 writes the int32 into a slice that is allocated using alloc.
+func FromInt32(i int32, alloc func(size int) []byte) []byte {
+	bs := alloc(4)
+	*(*int32)(unsafe.Pointer(&bs[0])) = i
+	return bs
 }
 
 func ToUint64(bs []byte) uint64 {
 	return *(*uint64)(unsafe.Pointer(&bs[0]))
 }
 
-// FromUint64 is very unsafe, you have make sure to keep the uint64 in a value that won't be freed, until you are doing using this slice.
-func FromUint64(i uint64, _alloc func(size int) []byte) []byte {
-	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
-		Len:  8,
-		Cap:  8,
-		Data: uintptr(unsafe.Pointer(&i)),
-	}))
+// FromUint64 writes the uint64 into a slice that is allocated using alloc.
+func FromUint64(i uint64, alloc func(size int) []byte) []byte {
+	bs := alloc(8)
+	*(*uint64)(unsafe.Pointer(&bs[0])) = i
+	return bs
 }
 
 func ToUint32(bs []byte) uint32 {
 	return *(*uint32)(unsafe.Pointer(&bs[0]))
 }
 
-// FromUint32 is very unsafe, you have make sure to keep the uint32 in a value that won't be freed, until you are doing using this slice.
-func FromUint32(i uint32, _alloc func(size int) []byte) []byte {
-	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
-		Len:  4,
-		Cap:  4,
-		Data: uintptr(unsafe.Pointer(&i)),
-	}))
+// FromUint32 writes the uint32 into a slice that is allocated using alloc.
+func FromUint32(i uint32, alloc func(size int) []byte) []byte {
+	bs := alloc(4)
+	*(*uint32)(unsafe.Pointer(&bs[0])) = i
+	return bs
 }
 
 func ToFloat64(bs []byte) float64 {
@@ -79,14 +70,11 @@ func ToFloat64(bs []byte) float64 {
 	return math.Float64frombits(u)
 }
 
-// FromFloat64 is very unsafe, you have make sure to keep the float64 in a value that won't be freed, until you are doing using this slice.
-func FromFloat64(f float64, _alloc func(size int) []byte) []byte {
-	u := math.Float64bits(f)
-	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
-		Len:  8,
-		Cap:  8,
-		Data: uintptr(unsafe.Pointer(&u)),
-	}))
+// FromFloat64 writes the float64 into a slice that is allocated using alloc.
+func FromFloat64(f float64, alloc func(size int) []byte) []byte {
+	bs := alloc(8)
+	*(*uint64)(unsafe.Pointer(&bs[0])) = math.Float64bits(f)
+	return bs
 }
 
 func ToFloat32(bs []byte) float32 {
@@ -94,14 +82,11 @@ func ToFloat32(bs []byte) float32 {
 	return math.Float32frombits(u)
 }
 
-// FromFloat32 is very unsafe, you have make sure to keep the float32 in a value that won't be freed, until you are doing using this slice.
-func FromFloat32(f float32, _alloc func(size int) []byte) []byte {
-	u := math.Float32bits(f)
-	return *(*[]byte)(unsafe.Pointer(&reflect.SliceHeader{
-		Len:  4,
-		Cap:  4,
-		Data: uintptr(unsafe.Pointer(&u)),
-	}))
+// FromFloat32 writes the float32 into a slice that is allocated using alloc.
+func FromFloat32(f float32, alloc func(size int) []byte) []byte {
+	bs := alloc(4)
+	*(*uint32)(unsafe.Pointer(&bs[0])) = math.Float32bits(f)
+	return bs
 }
 
 // ToString uses unsafe to cast a byte slice to a string without copying or allocating memory.
@@ -109,6 +94,8 @@ func ToString(buf []byte) string {
 	return unsafe.String(unsafe.SliceData(buf), len(buf))
 }
 
+// FromString uses unsafe to cast a string to a byte slice without copying or allocating memory.
+// The returned slice shares the string's memory, which means it is valid for as long as the string, but must not be modified.
 func FromString(s string, _alloc func(size int) []byte) []byte {
-	return *(*[]byte)(unsafe.Pointer(&s))
+	return unsafe.Slice(unsafe.StringData(s), len(s))
 }
@@ -0,0 +1,76 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package cast
+
+import "fmt"
+
+// LengthError is returned by the checked casts, when the byte slice does not have the length of the type.
+type LengthError struct {
+	Type string
+	Want int
+	Got  int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("cannot cast %d bytes to %s, which requires %d bytes", e.Got, e.Type, e.Want)
+}
+
+// ToInt64Checked is the same as ToInt64, but returns an error, instead of panicking or reading garbage, if bs is not 8 bytes long.
+func ToInt64Checked(bs []byte) (int64, error) {
+	if len(bs) != 8 {
+		return 0, &LengthError{Type: "int64", Want: 8, Got: len(bs)}
+	}
+	return ToInt64(bs), nil
+}
+
+// ToInt32Checked is the same as ToInt32, but returns an error, instead of panicking or reading garbage, if bs is not 4 bytes long.
+func ToInt32Checked(bs []byte) (int32, error) {
+	if len(bs) != 4 {
+		return 0, &LengthError{Type: "int32", Want: 4, Got: len(bs)}
+	}
+	return ToInt32(bs), nil
+}
+
+// ToUint64Checked is the same as ToUint64, but returns an error, instead of panicking or reading garbage, if bs is not 8 bytes long.
+func ToUint64Checked(bs []byte) (uint64, error) {
+	if len(bs) != 8 {
+		return 0, &LengthError{Type: "uint64", Want: 8, Got: len(bs)}
+	}
+	return ToUint64(bs), nil
+}
+
+// ToUint32Checked is the same as ToUint32, but returns an error, instead of panicking or reading garbage, if bs is not 4 bytes long.
+func ToUint32Checked(bs []byte) (uint32, error) {
+	if len(bs) != 4 {
+		return 0, &LengthError{Type: "uint32", Want: 4, Got: len(bs)}
+	}
+	return ToUint32(bs), nil
+}
+
+// ToFloat64Checked is the same as ToFloat64, but returns an error, instead of panicking or reading garbage, if bs is not 8 bytes long.
+func ToFloat64Checked(bs []byte) (float64, error) {
+	if len(bs) != 8 {
+		return 0, &LengthError{Type: "float64", Want: 8, Got: len(bs)}
+	}
+	return ToFloat64(bs), nil
+}
+
+// ToFloat32Checked is the same as ToFloat32, but returns an error, instead of panicking or reading garbage, if bs is not 4 bytes long.
+func ToFloat32Checked(bs []byte) (float32, error) {
+	if len(bs) != 4 {
+		return 0, &LengthError{Type: "float32", Want: 4, Got: len(bs)}
+	}
+	return ToFloat32(bs), nil
+}
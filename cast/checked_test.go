@@ -0,0 +1,112 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package cast
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCastInt64Checked(t *testing.T) {
+	alloc := func(size int) []byte { return make([]byte, size) }
+	got, err := ToInt64Checked(FromInt64(123, alloc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 123 {
+		t.Fatalf("want 123 got %d", got)
+	}
+	if _, err := ToInt64Checked([]byte{1, 2, 3}); err == nil {
+		t.Fatal("want error for short slice")
+	}
+	if _, err := ToInt64Checked(nil); err == nil {
+		t.Fatal("want error for nil slice")
+	}
+}
+
+// fuzzChecked checks that a checked cast never panics,
+// returns an error exactly when the length is wrong and otherwise agrees with the unchecked cast.
+func fuzzChecked[T comparable](t *testing.T, bs []byte, size int, checked func([]byte) (T, error), unchecked func([]byte) T) {
+	got, err := checked(bs)
+	if len(bs) != size {
+		if err == nil {
+			t.Fatalf("want error for %d bytes", len(bs))
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := unchecked(bs); got != want {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+func seed(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1})
+	f.Add([]byte{1, 2, 3, 4})
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9})
+}
+
+func FuzzToInt64Checked(f *testing.F) {
+	seed(f)
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		fuzzChecked(t, bs, 8, ToInt64Checked, ToInt64)
+		fuzzChecked(t, bs, 4, ToInt32Checked, ToInt32)
+		fuzzChecked(t, bs, 8, ToUint64Checked, ToUint64)
+		fuzzChecked(t, bs, 4, ToUint32Checked, ToUint32)
+	})
+}
+
+func FuzzToFloatChecked(f *testing.F) {
+	seed(f)
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		// Compare the bits, since NaN is not equal to itself.
+		fuzzChecked(t, bs, 8,
+			func(bs []byte) (uint64, error) {
+				f, err := ToFloat64Checked(bs)
+				return math.Float64bits(f), err
+			},
+			func(bs []byte) uint64 { return math.Float64bits(ToFloat64(bs)) },
+		)
+		fuzzChecked(t, bs, 4,
+			func(bs []byte) (uint32, error) {
+				f, err := ToFloat32Checked(bs)
+				return math.Float32bits(f), err
+			},
+			func(bs []byte) uint32 { return math.Float32bits(ToFloat32(bs)) },
+		)
+	})
+}
+
+func TestBigInt(t *testing.T) {
+	alloc := func(size int) []byte { return make([]byte, size) }
+	for _, s := range []string{"0", "-1", "18446744073709551616", "-340282366920938463463374607431768211457"} {
+		i, err := ToBigInt([]byte(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(FromBigInt(i, alloc)); got != s {
+			t.Fatalf("want %s, but got %s", s, got)
+		}
+	}
+	for _, s := range []string{"", "-", "1.5", "0x10", "1_000", " 1"} {
+		if _, err := ToBigInt([]byte(s)); err == nil {
+			t.Fatalf("expected an error for %q", s)
+		}
+	}
+}
@@ -17,6 +17,7 @@ package downgrade
 
 import (
 	"io"
+	"math"
 
 	"katydid.org.za/go/parser-go/cast"
 	"katydid.org.za/go/parser-go/parse"
@@ -39,6 +40,8 @@ type downgradeParser struct {
 	state   state
 	stack   []state
 	parser  parserWithInit
+	// length is the number of fields or elements of the object or array that was last entered, or -1 if it is unknown.
+	length int
 }
 
 // Parser downgrades a new parse.Parser implementation to an old parser.Interface implementation with an Init method.
@@ -51,6 +54,7 @@ func Parser(parser parse.Parser) parser.Interface {
 		stack:   make([]state, 0, 10),
 		actions: make([]action, 0, 10),
 		parser:  parserWithInit,
+		length:  -1,
 	}
 }
 
@@ -66,6 +70,7 @@ func ParserWithInit(parser parserWithInit) interfaceWithInit {
 		stack:   make([]state, 0, 10),
 		actions: make([]action, 0, 10),
 		parser:  parser,
+		length:  -1,
 	}
 }
 
@@ -75,6 +80,7 @@ func (p *downgradeParser) Init(buf []byte) error {
 	p.actions = p.actions[:0]
 	p.state = atStartState
 	p.stack = p.stack[:0]
+	p.length = -1
 	return nil
 }
 
@@ -88,6 +94,12 @@ func (p *downgradeParser) nextAtStartState(action action) error {
 		switch parseHint {
 		case parse.EnterHint:
 			p.state = atFieldState
+			// Capture the length, since it is only valid directly after the EnterHint.
+			if n, ok := parse.GetLen(p.parser); ok {
+				p.length = n
+			} else {
+				p.length = -1
+			}
 			parseHintNext, err := p.parser.Next()
 			if err != nil {
 				return err
@@ -345,24 +357,67 @@ func (p *downgradeParser) Bool() (bool, error) {
 	return false, errNotBool
 }
 
+// Int returns the value of an Int64Kind, Uint64Kind or BigIntKind token, if it fits into an int64.
 func (p *downgradeParser) Int() (int64, error) {
 	tokenKind, bs, err := p.parser.Token()
 	if err != nil {
 		return 0, err
 	}
-	if tokenKind != parse.Int64Kind {
-		return 0, errNotInt
+	switch tokenKind {
+	case parse.Int64Kind:
+		return cast.ToInt64Checked(bs)
+	case parse.Uint64Kind:
+		u, err := cast.ToUint64Checked(bs)
+		if err != nil {
+			return 0, err
+		}
+		if u > math.MaxInt64 {
+			return 0, errIntOverflow
+		}
+		return int64(u), nil
+	case parse.BigIntKind:
+		i, err := cast.ToBigInt(bs)
+		if err != nil {
+			return 0, err
+		}
+		if !i.IsInt64() {
+			return 0, errIntOverflow
+		}
+		return i.Int64(), nil
 	}
-	return cast.ToInt64(bs), nil
+	return 0, errNotInt
 }
 
+// Uint returns the value of an Int64Kind, Uint64Kind or BigIntKind token, if it fits into a uint64.
 func (p *downgradeParser) Uint() (uint64, error) {
-	i, err := p.Int()
+	tokenKind, bs, err := p.parser.Token()
 	if err != nil {
 		return 0, err
 	}
-	if i >= 0 {
+	switch tokenKind {
+	case parse.Int64Kind:
+		i, err := cast.ToInt64Checked(bs)
+		if err != nil {
+			return 0, err
+		}
+		if i < 0 {
+			return 0, errNotUint
+		}
 		return uint64(i), nil
+	case parse.Uint64Kind:
+		return cast.ToUint64Checked(bs)
+	case parse.BigIntKind:
+		i, err := cast.ToBigInt(bs)
+		if err != nil {
+			return 0, err
+		}
+		if i.Sign() < 0 {
+			return 0, errNotUint
+		}
+		if !i.IsUint64() {
+			return 0, errUintOverflow
+		}
+		return i.Uint64(), nil
 	}
 	return 0, errNotUint
 }
@@ -375,7 +430,7 @@ func (p *downgradeParser) Double() (float64, error) {
 	if tokenKind != parse.Float64Kind {
 		return 0, errNotFloat
 	}
-	return cast.ToFloat64(bs), nil
+	return cast.ToFloat64Checked(bs)
 }
 
 func (p *downgradeParser) String() (string, error) {
@@ -389,6 +444,23 @@ func (p *downgradeParser) String() (string, error) {
 	return cast.ToString(bs), nil
 }
 
+// Len returns the number of fields or elements of the object or array that was last entered, if the downgraded parser reported it, see parse.LenAble.
+func (p *downgradeParser) Len() (int, bool) {
+	return p.length, p.length >= 0
+}
+
+// Offset returns the offset of the current token of the downgraded parser, see parse.OffsetAble.
+func (p *downgradeParser) Offset() int64 {
+	offset, _ := parse.GetOffset(p.parser)
+	return offset
+}
+
+// Span returns the span of the current token of the downgraded parser, see parse.SpanAble.
+func (p *downgradeParser) Span() (int64, int64) {
+	start, end, _ := parse.GetSpan(p.parser)
+	return start, end
+}
+
 var nullBytes = []byte{'n', 'u', 'l', 'l'}
 
 func (p *downgradeParser) Bytes() ([]byte, error) {
@@ -0,0 +1,122 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package downgrade
+
+import (
+	"math"
+	"testing"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/internal/replaytest"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/replay"
+)
+
+func alloc(size int) []byte {
+	return make([]byte, size)
+}
+
+func newValue(t *testing.T, kind parse.Kind, value []byte) interfaceWithInit {
+	t.Helper()
+	buf := replaytest.Encode(t, func(enc *replaytest.Encoder) {
+		enc.Value(kind, value)
+	})
+	p := ParserWithInit(replay.NewParser())
+	if err := p.Init(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestIntOverflow(t *testing.T) {
+	tests := []struct {
+		kind   parse.Kind
+		value  []byte
+		int    int64
+		intOk  bool
+		uint   uint64
+		uintOk bool
+	}{
+		{parse.Int64Kind, cast.FromInt64(-1, alloc), -1, true, 0, false},
+		{parse.Uint64Kind, cast.FromUint64(math.MaxInt64, alloc), math.MaxInt64, true, math.MaxInt64, true},
+		{parse.Uint64Kind, cast.FromUint64(math.MaxUint64, alloc), 0, false, math.MaxUint64, true},
+		{parse.BigIntKind, []byte("-9223372036854775808"), math.MinInt64, true, 0, false},
+		{parse.BigIntKind, []byte("18446744073709551615"), 0, false, math.MaxUint64, true},
+		{parse.BigIntKind, []byte("18446744073709551616"), 0, false, 0, false},
+	}
+	for _, test := range tests {
+		p := newValue(t, test.kind, test.value)
+		i, err := p.Int()
+		if (err == nil) != test.intOk || i != test.int {
+			t.Errorf("Int of %v %x: got %d, %v", test.kind, test.value, i, err)
+		}
+		u, err := p.Uint()
+		if (err == nil) != test.uintOk || u != test.uint {
+			t.Errorf("Uint of %v %x: got %d, %v", test.kind, test.value, u, err)
+		}
+	}
+}
+
+// noSpan hides the Span and Offset methods of the parser.
+type noSpan struct {
+	parse.ParserWithInit
+}
+
+// offsetOnly hides the Span method of the parser, but keeps its Offset method.
+type offsetOnly struct {
+	parse.ParserWithInit
+	offset parse.OffsetAble
+}
+
+func (o offsetOnly) Offset() int64 {
+	return o.offset.Offset()
+}
+
+func TestSpanFallback(t *testing.T) {
+	buf := replaytest.Encode(t, func(enc *replaytest.Encoder) {
+		enc.Value(parse.StringKind, []byte("abc"))
+	})
+	r := replay.NewParser()
+	tests := []struct {
+		name       string
+		p          parse.ParserWithInit
+		start, end int64
+	}{
+		{"span", r, 3, 6},
+		{"offset only", offsetOnly{r, r.(parse.OffsetAble)}, 3, 3},
+		{"no span", noSpan{r}, -1, -1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := ParserWithInit(test.p)
+			if err := p.Init(buf); err != nil {
+				t.Fatal(err)
+			}
+			if err := p.Next(); err != nil {
+				t.Fatal(err)
+			}
+			start, end := p.(parse.SpanAble).Span()
+			if start != test.start || end != test.end {
+				t.Fatalf("want span [%d:%d], but got [%d:%d]", test.start, test.end, start, end)
+			}
+			if offset := p.(parse.OffsetAble).Offset(); offset != test.start {
+				t.Fatalf("want offset %d, but got %d", test.start, offset)
+			}
+		})
+	}
+}
@@ -32,6 +32,10 @@ var errNotUint = errors.New("not a uint")
 
 var errNotInt = errors.New("not a int")
 
+var errIntOverflow = errors.New("integer overflows int64")
+
+var errUintOverflow = errors.New("integer overflows uint64")
+
 var errNotBool = errors.New("not a bool")
 
 var errNotString = errors.New("not a string")
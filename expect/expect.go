@@ -17,6 +17,7 @@ package expect
 import (
 	"fmt"
 	"io"
+	"math/big"
 	"testing"
 
 	"katydid.org.za/go/parser-go/cast"
@@ -65,12 +66,51 @@ func Int(t *testing.T, tzer parse.Parser, want int64) {
 	if tokenKind != parse.Int64Kind {
 		t.Fatalf("expected int64, but got %v", tokenKind)
 	}
-	got := cast.ToInt64(gotb)
+	got, err := cast.ToInt64Checked(gotb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+func Uint(t *testing.T, tzer parse.Parser, want uint64) {
+	t.Helper()
+	tokenKind, gotb, err := tzer.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokenKind != parse.Uint64Kind {
+		t.Fatalf("expected uint64, but got %v", tokenKind)
+	}
+	got, err := cast.ToUint64Checked(gotb)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if got != want {
 		t.Fatalf("want %v, but got %v", want, got)
 	}
 }
 
+func BigInt(t *testing.T, tzer parse.Parser, want *big.Int) {
+	t.Helper()
+	tokenKind, gotb, err := tzer.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokenKind != parse.BigIntKind {
+		t.Fatalf("expected bigInt, but got %v", tokenKind)
+	}
+	got, err := cast.ToBigInt(gotb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Cmp(want) != 0 {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
 func Float(t *testing.T, tzer parse.Parser, want float64) {
 	t.Helper()
 	tokenKind, gotb, err := tzer.Token()
@@ -80,7 +120,10 @@ func Float(t *testing.T, tzer parse.Parser, want float64) {
 	if tokenKind != parse.Float64Kind {
 		t.Fatalf("expected float64, but got %v", tokenKind)
 	}
-	got := cast.ToFloat64(gotb)
+	got, err := cast.ToFloat64Checked(gotb)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if got != want {
 		t.Fatalf("want %v, but got %v", want, got)
 	}
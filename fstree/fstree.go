@@ -0,0 +1,257 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package fstree parses a directory tree from an fs.FS.
+//
+// Directories are parsed as objects keyed by entry name.
+// Files are parsed as objects with the fields:
+//   - "size": the size in bytes (parse.Int64Kind).
+//   - "mode": the file mode, for example "-rw-r--r--" (parse.StringKind).
+//   - "modTime": the modification time in RFC 3339 format (parse.DateTimeKind).
+//   - "contents": the contents of the file (parse.BytesKind), only if WithContents was passed as an option.
+//
+// For example a directory containing a single file is parsed as:
+// `{"a.txt": {"size": 5, "mode": "-rw-r--r--", "modTime": "2026-01-02T03:04:05Z"}}`.
+package fstree
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"time"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+type Parser interface {
+	parse.ParserWithInit
+	jsonschema.JSONSchemaAble
+	Reset()
+}
+
+const (
+	sizeField     = "size"
+	modeField     = "mode"
+	modTimeField  = "modTime"
+	contentsField = "contents"
+)
+
+var fileFields = []string{sizeField, modeField, modTimeField, contentsField}
+
+type parser struct {
+	fsys     fs.FS
+	root     string
+	contents bool
+	// state
+	hint  parse.Hint
+	done  bool
+	stack []frame
+}
+
+// frame represents a directory or a file that has been entered.
+type frame struct {
+	path string
+	info fs.FileInfo
+	// entries are only read once Next is called inside the directory,
+	// so that skipped directories are never read.
+	entries     []fs.DirEntry
+	entriesRead bool
+	// data is only read once Token is called on the contents field.
+	data     []byte
+	dataRead bool
+	index    int
+}
+
+// NewParser returns a parser for the directory tree of fsys.
+// The tree starts at the root ".", unless another root is passed to Init.
+func NewParser(fsys fs.FS, opts ...Option) Parser {
+	p := &parser{
+		fsys:  fsys,
+		root:  ".",
+		stack: make([]frame, 0, 10),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Init resets the parser and sets the root path, for example "release/v1".
+// An empty buf sets the root to ".".
+func (p *parser) Init(buf []byte) {
+	p.root = "."
+	if len(buf) > 0 {
+		p.root = string(buf)
+	}
+	p.Reset()
+}
+
+func (p *parser) Reset() {
+	p.hint = parse.UnknownHint
+	p.done = false
+	// Shrink the stack's length, but keep it's capacity,
+	// so we can reuse it on the next parse.
+	p.stack = p.stack[:0]
+}
+
+func (p *parser) JSONSchemaType() jsonschema.JSONSchemaType {
+	// Both directories and files are objects.
+	return jsonschema.JSONSchemaTypeObject
+}
+
+func (p *parser) Next() (parse.Hint, error) {
+	if len(p.stack) == 0 {
+		if p.done {
+			return parse.UnknownHint, io.EOF
+		}
+		info, err := fs.Stat(p.fsys, p.root)
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		p.push(p.root, info)
+		p.hint = parse.EnterHint
+		return p.hint, nil
+	}
+	if p.hint == parse.FieldHint {
+		return p.nextValue()
+	}
+	return p.nextField()
+}
+
+func (p *parser) nextValue() (parse.Hint, error) {
+	top := p.top()
+	if !top.info.IsDir() {
+		p.hint = parse.ValueHint
+		return p.hint, nil
+	}
+	entry := top.entries[top.index]
+	info, err := entry.Info()
+	if err != nil {
+		return parse.UnknownHint, err
+	}
+	p.push(path.Join(top.path, entry.Name()), info)
+	p.hint = parse.EnterHint
+	return p.hint, nil
+}
+
+func (p *parser) nextField() (parse.Hint, error) {
+	top := p.top()
+	if top.info.IsDir() && !top.entriesRead {
+		entries, err := fs.ReadDir(p.fsys, top.path)
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		top.entries = entries
+		top.entriesRead = true
+	}
+	top.index++
+	if top.index < p.numFields(top) {
+		p.hint = parse.FieldHint
+		return p.hint, nil
+	}
+	p.pop()
+	p.hint = parse.LeaveHint
+	return p.hint, nil
+}
+
+func (p *parser) numFields(f *frame) int {
+	if f.info.IsDir() {
+		return len(f.entries)
+	}
+	if p.contents {
+		return len(fileFields)
+	}
+	return len(fileFields) - 1
+}
+
+func (p *parser) Skip() error {
+	switch p.hint {
+	case parse.EnterHint:
+		// Skip the whole directory or file, without reading it.
+		p.pop()
+	case parse.FieldHint:
+		// Skip the entry or file field's value, by not entering it.
+	case parse.ValueHint:
+		// Skip the rest of the file's fields.
+		p.pop()
+	default:
+		_, err := p.Next()
+		return err
+	}
+	p.hint = parse.UnknownHint
+	return nil
+}
+
+func (p *parser) Token() (parse.Kind, []byte, error) {
+	switch p.hint {
+	case parse.FieldHint:
+		top := p.top()
+		if top.info.IsDir() {
+			return parse.StringKind, cast.FromString(top.entries[top.index].Name(), alloc), nil
+		}
+		return parse.StringKind, cast.FromString(fileFields[top.index], alloc), nil
+	case parse.ValueHint:
+		return p.fileToken(p.top())
+	}
+	return parse.UnknownKind, nil, nil
+}
+
+func (p *parser) fileToken(f *frame) (parse.Kind, []byte, error) {
+	switch fileFields[f.index] {
+	case sizeField:
+		return parse.Int64Kind, cast.FromInt64(f.info.Size(), alloc), nil
+	case modeField:
+		return parse.StringKind, cast.FromString(f.info.Mode().String(), alloc), nil
+	case modTimeField:
+		modTime := f.info.ModTime().UTC().Format(time.RFC3339Nano)
+		return parse.DateTimeKind, cast.FromString(modTime, alloc), nil
+	case contentsField:
+		if !f.dataRead {
+			data, err := fs.ReadFile(p.fsys, f.path)
+			if err != nil {
+				return parse.UnknownKind, nil, err
+			}
+			f.data = data
+			f.dataRead = true
+		}
+		return parse.BytesKind, f.data, nil
+	}
+	panic("unreachable")
+}
+
+func (p *parser) top() *frame {
+	return &p.stack[len(p.stack)-1]
+}
+
+func (p *parser) push(path string, info fs.FileInfo) {
+	p.stack = append(p.stack, frame{
+		path:  path,
+		info:  info,
+		index: -1,
+	})
+}
+
+func (p *parser) pop() {
+	p.stack = p.stack[:len(p.stack)-1]
+	if len(p.stack) == 0 {
+		p.done = true
+	}
+}
+
+// alloc allocates the tokens that Token encodes from a directory entry or its fs.FileInfo, like the size of a file.
+func alloc(size int) []byte {
+	return make([]byte, size)
+}
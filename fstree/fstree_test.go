@@ -0,0 +1,143 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package fstree
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"katydid.org.za/go/parser-go/expect"
+	"katydid.org.za/go/parser-go/hedge"
+	"katydid.org.za/go/parser-go/internal/fstreetest"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/parse/debug"
+	"katydid.org.za/go/parser-go/rand"
+)
+
+func file(name string, size string, mode string, extra ...hedge.Node) hedge.Node {
+	fields := []hedge.Node{
+		hedge.Field("size", size),
+		hedge.Field("mode", mode),
+		hedge.Field("modTime", "2026-01-02T03:04:05Z"),
+	}
+	return hedge.Nested(name, append(fields, extra...)...)
+}
+
+func TestParseInto(t *testing.T) {
+	p := NewParser(fstreetest.NewFS())
+	got, err := hedge.ParseInto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := hedge.Hedge{
+		file("README.md", "5", "-rw-r--r--"),
+		hedge.Nested("bin",
+			file("tool", "2", "-rwxr-xr-x"),
+		),
+		hedge.Nested("docs",
+			hedge.Nested("a",
+				file("b.txt", "1", "-rw-------"),
+			),
+		),
+	}
+	if !got.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+func TestContents(t *testing.T) {
+	p := NewParser(fstreetest.NewFS(), WithContents())
+	p.Init([]byte("bin"))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "tool")
+	expect.Hint(t, p, parse.EnterHint)
+	for _, name := range []string{"size", "mode", "modTime"} {
+		expect.Hint(t, p, parse.FieldHint)
+		expect.String(t, p, name)
+		expect.NoErr(t, p.Skip)
+	}
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "contents")
+	expect.Hint(t, p, parse.ValueHint)
+	kind, data, err := p.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != parse.BytesKind || string(data) != "#!" {
+		t.Fatalf("want bytes `#!`, but got %v `%s`", kind, data)
+	}
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+type countingFS struct {
+	fstest.MapFS
+	reads map[string]int
+}
+
+func (c *countingFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	c.reads[name]++
+	return c.MapFS.ReadDir(name)
+}
+
+func TestSkipDoesNotReadDir(t *testing.T) {
+	fsys := &countingFS{fstreetest.NewFS(), map[string]int{}}
+	p := NewParser(fsys)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "README.md")
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "bin")
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "docs")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	if fsys.reads["."] != 1 {
+		t.Fatalf("want root read once, but got %d", fsys.reads["."])
+	}
+	if fsys.reads["bin"] != 0 || fsys.reads["docs"] != 0 {
+		t.Fatalf("want skipped directories to not be read, but got %v", fsys.reads)
+	}
+}
+
+func TestSkipRestOfFile(t *testing.T) {
+	p := NewParser(fstreetest.NewFS())
+	p.Init([]byte("docs/a/b.txt"))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "size")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.NoErr(t, p.Skip)
+	expect.EOF(t, p)
+}
+
+func TestRandomWalk(t *testing.T) {
+	p := NewParser(fstreetest.NewFS(), WithContents())
+	r := rand.NewRand()
+	for i := 0; i < 100; i++ {
+		p.Reset()
+		if err := debug.RandomWalk(p, r, 10, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package fstree
+
+// Option is used set options when creating a new fs.FS Parser.
+type Option func(*parser)
+
+// WithContents adds a "contents" field to each file, which contains the contents of the file as parse.BytesKind.
+// Contents are only read when the Token method is called on the "contents" field's value.
+func WithContents() func(*parser) {
+	return func(p *parser) {
+		p.contents = true
+	}
+}
@@ -22,9 +22,33 @@ import (
 	. "katydid.org.za/go/parser-go/rand"
 )
 
+// maxPrealloc limits the number of nodes that are preallocated, since the length reported by a parser could come from untrusted input.
+const maxPrealloc = 1 << 10
+
+// prealloc returns the number of nodes to preallocate for the children of the object or array that was just entered, see parse.LenAble.
+func prealloc[P parse.Parser](p P) int {
+	n, ok := parse.GetLen(p)
+	if !ok {
+		return 0
+	}
+	return min(n, maxPrealloc)
+}
+
 // ParseInto parses through the whole parser in a top down manner and records the values into a Nodes structute.
+// If the parser implements parse.LenAble, then the children of objects and arrays are preallocated.
 func ParseInto(p parse.Parser) (Hedge, error) {
-	nodes := make(Hedge, 0)
+	return parseInto(p, 0)
+}
+
+// ParseIntoOf is the same as ParseInto, but is generic over the type of the parser.
+// When P is a concrete type, the compiler can inline calls to the parser,
+// instead of calling it through an interface.
+func ParseIntoOf[P parse.Parser](p P) (Hedge, error) {
+	return parseInto(p, 0)
+}
+
+func parseInto[P parse.Parser](p P, size int) (Hedge, error) {
+	nodes := make(Hedge, 0, size)
 	for {
 		hint, err := p.Next()
 		if err != nil {
@@ -35,16 +59,18 @@ func ParseInto(p parse.Parser) (Hedge, error) {
 		}
 		switch hint {
 		case parse.EnterHint:
-			children, err := ParseInto(p)
+			children, err := parseInto(p, prealloc(p))
 			if err != nil {
 				return nil, err
 			}
 			nodes = append(nodes, children...)
 		case parse.FieldHint:
-			name, err := parse.GetValue(p)
+			value, err := parse.GetValue(p)
 			if err != nil {
 				return nil, err
 			}
+			// Format the name before calling Next, since the token's bytes are only valid until then.
+			name := fmt.Sprintf("%v", value)
 			childHint, err := p.Next()
 			if err != nil {
 				return nil, err
@@ -55,13 +81,13 @@ func ParseInto(p parse.Parser) (Hedge, error) {
 				if err != nil {
 					return nil, err
 				}
-				nodes = append(nodes, Node{Label: fmt.Sprintf("%v", name), Children: []Node{{Label: fmt.Sprintf("%v", val)}}})
+				nodes = append(nodes, Node{Label: name, Children: []Node{{Label: fmt.Sprintf("%v", val)}}})
 			case parse.EnterHint:
-				children, err := ParseInto(p)
+				children, err := parseInto(p, prealloc(p))
 				if err != nil {
 					return nil, err
 				}
-				nodes = append(nodes, Node{Label: fmt.Sprintf("%v", name), Children: children})
+				nodes = append(nodes, Node{Label: name, Children: children})
 			}
 		case parse.ValueHint:
 			val, err := parse.GetValue(p)
@@ -102,10 +128,12 @@ func RandomParseInto(p parse.Parser, r Rand, next, skip int) (Hedge, error) {
 			}
 			nodes = append(nodes, children...)
 		case parse.FieldHint:
-			name, err := parse.GetValue(p)
+			value, err := parse.GetValue(p)
 			if err != nil {
 				return nil, err
 			}
+			// Format the name before calling Next, since the token's bytes are only valid until then.
+			name := fmt.Sprintf("%v", value)
 			if r.Intn(skip) == 0 {
 				p.Skip()
 			} else {
@@ -119,13 +147,13 @@ func RandomParseInto(p parse.Parser, r Rand, next, skip int) (Hedge, error) {
 					if err != nil {
 						return nil, err
 					}
-					nodes = append(nodes, Node{Label: fmt.Sprintf("%v", name), Children: []Node{{Label: fmt.Sprintf("%v", val)}}})
+					nodes = append(nodes, Node{Label: name, Children: []Node{{Label: fmt.Sprintf("%v", val)}}})
 				case parse.EnterHint:
 					children, err := RandomParseInto(p, r, next, skip)
 					if err != nil {
 						return nil, err
 					}
-					nodes = append(nodes, Node{Label: fmt.Sprintf("%v", name), Children: children})
+					nodes = append(nodes, Node{Label: name, Children: children})
 				}
 			}
 		case parse.ValueHint:
@@ -0,0 +1,281 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package httptree parses an *http.Request or *http.Response.
+//
+// A request is parsed as an object with the fields:
+//   - "method": for example "POST".
+//   - "path": the segments of the URL path, for example `["api", "users"]`.
+//   - "query": the query parameters, for example `{"id": ["1", "2"]}`.
+//   - "header": the headers, for example `{"Content-Type": ["application/json"]}`.
+//   - "cookies": the cookies, for example `{"session": "abc"}`.
+//   - "form": the form values, only for form media types, for example `{"name": ["abc"]}`.
+//   - "body": the body, only if there is a body and it is not a form.
+//
+// A response is parsed as an object with the fields "status", "header", "cookies" and "body".
+//
+// The body is parsed as parse.BytesKind, unless WithBodyParser is used to delegate parsing of the body.
+// The body is only read if the "body" or "form" field's value is not skipped.
+package httptree
+
+import (
+	"io"
+	"net/http"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+type Parser interface {
+	parse.Parser
+	jsonschema.JSONSchemaAble
+	Reset()
+}
+
+type parser struct {
+	root          *object
+	newBodyParser func(mediaType string) parse.ParserWithInit
+	// state
+	hint  parse.Hint
+	leaf  leaf
+	done  bool
+	stack []frame
+	// sub is the parser that the body is delegated to.
+	// subDepth is the number of objects and arrays that are open in sub.
+	sub      parse.Parser
+	subDepth int
+}
+
+type frame struct {
+	object *object
+	array  *array
+	index  int
+}
+
+func (f *frame) len() int {
+	if f.object != nil {
+		return len(f.object.values)
+	}
+	return len(f.array.values)
+}
+
+func (f *frame) value() node {
+	if f.object != nil {
+		return f.object.values[f.index]
+	}
+	return f.array.values[f.index]
+}
+
+// NewRequestParser returns a parser for the request.
+func NewRequestParser(r *http.Request, opts ...Option) Parser {
+	return newParser(requestTree(r), opts...)
+}
+
+// NewResponseParser returns a parser for the response.
+func NewResponseParser(resp *http.Response, opts ...Option) Parser {
+	return newParser(responseTree(resp), opts...)
+}
+
+func newParser(root *object, opts ...Option) *parser {
+	p := &parser{
+		root:  root,
+		stack: make([]frame, 0, 4),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Reset resets the parser to parse the request or response again.
+// The body is not read again.
+func (p *parser) Reset() {
+	p.hint = parse.UnknownHint
+	p.done = false
+	p.stack = p.stack[:0]
+	p.sub = nil
+	p.subDepth = 0
+}
+
+func (p *parser) JSONSchemaType() jsonschema.JSONSchemaType {
+	if p.sub != nil {
+		if s, ok := p.sub.(jsonschema.JSONSchemaAble); ok {
+			return s.JSONSchemaType()
+		}
+		return jsonschema.JSONSchemaTypeUnknown
+	}
+	if len(p.stack) == 0 {
+		return jsonschema.JSONSchemaTypeUnknown
+	}
+	if p.top().array != nil {
+		return jsonschema.JSONSchemaTypeArray
+	}
+	return jsonschema.JSONSchemaTypeObject
+}
+
+func (p *parser) Next() (parse.Hint, error) {
+	if p.sub != nil {
+		if p.subDepth > 0 {
+			return p.nextSub()
+		}
+		p.sub = nil
+	}
+	if len(p.stack) == 0 {
+		if p.done {
+			return parse.UnknownHint, io.EOF
+		}
+		p.stack = append(p.stack, frame{object: p.root, index: -1})
+		p.hint = parse.EnterHint
+		return p.hint, nil
+	}
+	top := p.top()
+	if p.hint == parse.FieldHint {
+		return p.nextValue(top.value())
+	}
+	top.index++
+	if top.index >= top.len() {
+		p.pop()
+		p.hint = parse.LeaveHint
+		return p.hint, nil
+	}
+	if top.object != nil {
+		p.hint = parse.FieldHint
+		return p.hint, nil
+	}
+	return p.nextValue(top.value())
+}
+
+func (p *parser) nextValue(n node) (parse.Hint, error) {
+	switch n := n.(type) {
+	case leaf:
+		p.leaf = n
+		p.hint = parse.ValueHint
+	case *object:
+		p.stack = append(p.stack, frame{object: n, index: -1})
+		p.hint = parse.EnterHint
+	case *array:
+		p.stack = append(p.stack, frame{array: n, index: -1})
+		p.hint = parse.EnterHint
+	case *form:
+		o, err := n.object()
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		return p.nextValue(o)
+	case *body:
+		data, err := n.bytes()
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		var sub parse.ParserWithInit
+		if p.newBodyParser != nil {
+			sub = p.newBodyParser(n.mediaType)
+		}
+		if sub == nil {
+			p.leaf = leaf{parse.BytesKind, data}
+			p.hint = parse.ValueHint
+			return p.hint, nil
+		}
+		sub.Init(data)
+		p.sub = sub
+		p.subDepth = 0
+		return p.nextSub()
+	default:
+		panic("unreachable")
+	}
+	return p.hint, nil
+}
+
+func (p *parser) nextSub() (parse.Hint, error) {
+	h, err := p.sub.Next()
+	if err != nil {
+		return parse.UnknownHint, err
+	}
+	switch h {
+	case parse.EnterHint:
+		p.subDepth++
+	case parse.LeaveHint:
+		p.subDepth--
+	}
+	p.hint = h
+	return p.hint, nil
+}
+
+func (p *parser) Skip() error {
+	if p.sub != nil && p.subDepth > 0 {
+		return p.skipSub()
+	}
+	switch p.hint {
+	case parse.EnterHint:
+		// Skip the whole object or array.
+		p.pop()
+	case parse.FieldHint:
+		// Skip the field's value, by not resolving it.
+	case parse.ValueHint:
+		// Skip the rest of the object or array.
+		p.pop()
+	default:
+		_, err := p.Next()
+		return err
+	}
+	p.sub = nil
+	p.hint = parse.UnknownHint
+	return nil
+}
+
+func (p *parser) skipSub() error {
+	switch p.hint {
+	case parse.EnterHint, parse.ValueHint:
+		// Skip the whole object or array that was entered, or the rest of the object or array that the value is in.
+		if err := p.sub.Skip(); err != nil {
+			return err
+		}
+		p.subDepth--
+	case parse.FieldHint:
+		if err := p.sub.Skip(); err != nil {
+			return err
+		}
+	default:
+		_, err := p.Next()
+		return err
+	}
+	p.hint = parse.UnknownHint
+	return nil
+}
+
+func (p *parser) Token() (parse.Kind, []byte, error) {
+	if p.sub != nil {
+		return p.sub.Token()
+	}
+	switch p.hint {
+	case parse.FieldHint:
+		top := p.top()
+		return parse.StringKind, cast.FromString(top.object.names[top.index], alloc), nil
+	case parse.ValueHint:
+		return p.leaf.kind, p.leaf.value, nil
+	}
+	return parse.UnknownKind, nil, nil
+}
+
+func (p *parser) top() *frame {
+	return &p.stack[len(p.stack)-1]
+}
+
+func (p *parser) pop() {
+	p.stack = p.stack[:len(p.stack)-1]
+	if len(p.stack) == 0 {
+		p.done = true
+	}
+}
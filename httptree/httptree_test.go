@@ -0,0 +1,266 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package httptree
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"katydid.org.za/go/parser-go/expect"
+	"katydid.org.za/go/parser-go/hedge"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/parse/debug"
+	"katydid.org.za/go/parser-go/rand"
+)
+
+func TestRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/users?id=1&id=2", nil)
+	r.Header.Set("X-Api-Key", "secret")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	got, err := hedge.ParseInto(NewRequestParser(r))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := hedge.Hedge{
+		hedge.Field("method", "GET"),
+		hedge.Nested("path",
+			hedge.Node{Label: "api"},
+			hedge.Node{Label: "users"},
+		),
+		hedge.Nested("query",
+			hedge.Nested("id",
+				hedge.Node{Label: "1"},
+				hedge.Node{Label: "2"},
+			),
+		),
+		hedge.Nested("header",
+			hedge.Nested("Cookie", hedge.Node{Label: "session=abc"}),
+			hedge.Nested("X-Api-Key", hedge.Node{Label: "secret"}),
+		),
+		hedge.Nested("cookies",
+			hedge.Field("session", "abc"),
+		),
+	}
+	if !got.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+type countingReader struct {
+	io.Reader
+	reads int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	c.reads++
+	return c.Reader.Read(p)
+}
+
+func skipToField(t *testing.T, p parse.Parser, name string) {
+	t.Helper()
+	for {
+		expect.Hint(t, p, parse.FieldHint)
+		kind, got, err := p.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind == parse.StringKind && string(got) == name {
+			return
+		}
+		expect.NoErr(t, p.Skip)
+	}
+}
+
+func TestSkipBodyIsNotRead(t *testing.T) {
+	body := &countingReader{Reader: strings.NewReader("hello")}
+	r := httptest.NewRequest("POST", "/", body)
+	p := NewRequestParser(r)
+	expect.Hint(t, p, parse.EnterHint)
+	skipToField(t, p, "body")
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	if body.reads != 0 {
+		t.Fatalf("want body not read, but got %d reads", body.reads)
+	}
+}
+
+func TestBodyBytes(t *testing.T) {
+	r := httptest.NewRequest("PUT", "/", strings.NewReader("hello"))
+	p := NewRequestParser(r)
+	expect.Hint(t, p, parse.EnterHint)
+	skipToField(t, p, "body")
+	expect.Hint(t, p, parse.ValueHint)
+	kind, got, err := p.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != parse.BytesKind || string(got) != "hello" {
+		t.Fatalf("want bytes `hello`, but got %v `%s`", kind, got)
+	}
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	// The body can still be read by the next handler.
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("want body `hello`, but got `%s`", data)
+	}
+}
+
+// csv is a small parser that parses comma separated values as an array of strings.
+type csv struct {
+	values [][]byte
+	index  int
+}
+
+func (c *csv) Init(buf []byte) {
+	c.values = bytes.Split(buf, []byte(","))
+	c.index = -2
+}
+
+func (c *csv) Next() (parse.Hint, error) {
+	c.index++
+	switch {
+	case c.index == -1:
+		return parse.EnterHint, nil
+	case c.index < len(c.values):
+		return parse.ValueHint, nil
+	case c.index == len(c.values):
+		return parse.LeaveHint, nil
+	}
+	return parse.UnknownHint, io.EOF
+}
+
+func (c *csv) Skip() error {
+	if c.index < len(c.values) {
+		c.index = len(c.values)
+		return nil
+	}
+	_, err := c.Next()
+	return err
+}
+
+func (c *csv) Token() (parse.Kind, []byte, error) {
+	if c.index < 0 || c.index >= len(c.values) {
+		return parse.UnknownKind, nil, nil
+	}
+	return parse.StringKind, c.values[c.index], nil
+}
+
+func newCSV(mediaType string) parse.ParserWithInit {
+	if mediaType != "text/csv" {
+		return nil
+	}
+	return &csv{}
+}
+
+func TestBodyParser(t *testing.T) {
+	r := httptest.NewRequest("POST", "/items", strings.NewReader("a,b"))
+	r.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	p := NewRequestParser(r, WithBodyParser(newCSV))
+	got, err := hedge.ParseInto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := got[len(got)-1]
+	want := hedge.Nested("body",
+		hedge.Node{Label: "a"},
+		hedge.Node{Label: "b"},
+	)
+	if !body.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, body)
+	}
+}
+
+func TestBodyParserSkip(t *testing.T) {
+	r := httptest.NewRequest("POST", "/items", strings.NewReader("a,b,c"))
+	r.Header.Set("Content-Type", "text/csv")
+	p := NewRequestParser(r, WithBodyParser(newCSV))
+	expect.Hint(t, p, parse.EnterHint)
+	skipToField(t, p, "body")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "a")
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestForm(t *testing.T) {
+	r := httptest.NewRequest("POST", "/login", strings.NewReader("user=abc&remember=on"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	p := NewRequestParser(r)
+	expect.Hint(t, p, parse.EnterHint)
+	skipToField(t, p, "form")
+	got, err := hedge.ParseInto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := hedge.Hedge{
+		hedge.Nested("remember", hedge.Node{Label: "on"}),
+		hedge.Nested("user", hedge.Node{Label: "abc"}),
+	}
+	if !got.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+func TestResponse(t *testing.T) {
+	w := httptest.NewRecorder()
+	http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+	w.WriteHeader(http.StatusCreated)
+	w.WriteString("done")
+	p := NewResponseParser(w.Result())
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "status")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, http.StatusCreated)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "header")
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "cookies")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "session")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "abc")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "body")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestRandomWalk(t *testing.T) {
+	r := rand.NewRand()
+	for i := 0; i < 100; i++ {
+		req := httptest.NewRequest("POST", "/a/b?c=d", strings.NewReader("x,y,z"))
+		req.Header.Set("Content-Type", "text/csv")
+		p := NewRequestParser(req, WithBodyParser(newCSV))
+		if err := debug.RandomWalk(p, r, 10, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package httptree
+
+import "katydid.org.za/go/parser-go/parse"
+
+// Option is used set options when creating a new HTTP Parser.
+type Option func(*parser)
+
+// WithBodyParser delegates parsing of the body to the parser returned by newParser,
+// given the media type of the Content-Type header, for example "application/json".
+// The body then appears as a nested subtree under the "body" field.
+// If newParser returns nil, the body is parsed as parse.BytesKind.
+func WithBodyParser(newParser func(mediaType string) parse.ParserWithInit) func(*parser) {
+	return func(p *parser) {
+		p.newBodyParser = newParser
+	}
+}
@@ -0,0 +1,190 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package httptree
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// node is one of: *object, *array, leaf, *form or *body.
+type node any
+
+type object struct {
+	names  []string
+	values []node
+}
+
+func (o *object) add(name string, value node) {
+	o.names = append(o.names, name)
+	o.values = append(o.values, value)
+}
+
+type array struct {
+	values []node
+}
+
+type leaf struct {
+	kind  parse.Kind
+	value []byte
+}
+
+func stringLeaf(s string) leaf {
+	return leaf{parse.StringKind, cast.FromString(s, alloc)}
+}
+
+// form is only parsed when it is entered, since parsing a form reads the body.
+type form struct {
+	r      *http.Request
+	values *object
+}
+
+func (f *form) object() (*object, error) {
+	if f.values != nil {
+		return f.values, nil
+	}
+	if strings.HasPrefix(f.r.Header.Get("Content-Type"), "multipart/") {
+		if err := f.r.ParseMultipartForm(maxMemory); err != nil {
+			return nil, err
+		}
+	} else if err := f.r.ParseForm(); err != nil {
+		return nil, err
+	}
+	f.values = valuesObject(f.r.PostForm)
+	return f.values, nil
+}
+
+// maxMemory is the same default that net/http uses for multipart forms.
+const maxMemory = 32 << 20
+
+// body is only read when it is entered.
+type body struct {
+	rc        *io.ReadCloser
+	mediaType string
+	data      []byte
+	read      bool
+}
+
+func (b *body) bytes() ([]byte, error) {
+	if b.read {
+		return b.data, nil
+	}
+	data, err := io.ReadAll(*b.rc)
+	if err != nil {
+		return nil, err
+	}
+	if err := (*b.rc).Close(); err != nil {
+		return nil, err
+	}
+	// Replace the body, so that it can still be read by the next handler.
+	*b.rc = io.NopCloser(bytes.NewReader(data))
+	b.data = data
+	b.read = true
+	return b.data, nil
+}
+
+func newBody(rc *io.ReadCloser, header http.Header) node {
+	if *rc == nil || *rc == http.NoBody {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	return &body{rc: rc, mediaType: mediaType}
+}
+
+func isForm(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data"
+}
+
+func requestTree(r *http.Request) *object {
+	root := &object{}
+	root.add("method", stringLeaf(r.Method))
+	root.add("path", pathArray(r.URL.Path))
+	root.add("query", valuesObject(r.URL.Query()))
+	root.add("header", valuesObject(url.Values(r.Header)))
+	root.add("cookies", cookiesObject(r.Cookies()))
+	if isForm(r) {
+		root.add("form", &form{r: r})
+	} else if b := newBody(&r.Body, r.Header); b != nil {
+		root.add("body", b)
+	}
+	return root
+}
+
+func responseTree(resp *http.Response) *object {
+	root := &object{}
+	root.add("status", leaf{parse.Int64Kind, cast.FromInt64(int64(resp.StatusCode), alloc)})
+	root.add("header", valuesObject(url.Values(resp.Header)))
+	root.add("cookies", cookiesObject(resp.Cookies()))
+	if b := newBody(&resp.Body, resp.Header); b != nil {
+		root.add("body", b)
+	}
+	return root
+}
+
+// pathArray splits the path into segments, for example "/api/users" is parsed as `["api", "users"]`.
+func pathArray(path string) *array {
+	a := &array{}
+	path = strings.Trim(path, "/")
+	if len(path) == 0 {
+		return a
+	}
+	for _, segment := range strings.Split(path, "/") {
+		a.values = append(a.values, stringLeaf(segment))
+	}
+	return a
+}
+
+// valuesObject parses values as an object of arrays, for example `{"a": ["1", "2"]}`.
+// Keys are sorted, so that the order is deterministic.
+func valuesObject(values url.Values) *object {
+	o := &object{}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		a := &array{}
+		for _, value := range values[key] {
+			a.values = append(a.values, stringLeaf(value))
+		}
+		o.add(key, a)
+	}
+	return o
+}
+
+// cookiesObject parses cookies in the order they were sent, for example `{"session": "abc"}`.
+func cookiesObject(cookies []*http.Cookie) *object {
+	o := &object{}
+	for _, cookie := range cookies {
+		o.add(cookie.Name, stringLeaf(cookie.Value))
+	}
+	return o
+}
+
+// Leaves are encoded once, when the tree of a request or response is built, and live as long as the tree,
+// so there is nothing that a pool could reuse.
+func alloc(size int) []byte {
+	return make([]byte, size)
+}
@@ -0,0 +1,37 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package fstreetest provides the file system that the tests of fstree and its consumers parse.
+package fstreetest
+
+import (
+	"testing/fstest"
+	"time"
+)
+
+// ModTime is the modification time of all the files in NewFS.
+var ModTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// NewFS returns a small file system with a file, an executable and a nested directory:
+//
+//	README.md
+//	bin/tool
+//	docs/a/b.txt
+func NewFS() fstest.MapFS {
+	return fstest.MapFS{
+		"README.md":    {Data: []byte("hello"), Mode: 0644, ModTime: ModTime},
+		"bin/tool":     {Data: []byte("#!"), Mode: 0755, ModTime: ModTime},
+		"docs/a/b.txt": {Data: []byte("b"), Mode: 0600, ModTime: ModTime},
+	}
+}
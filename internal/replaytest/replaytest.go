@@ -0,0 +1,80 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package replaytest builds token streams, which tests replay as the parser that is wrapped by the parser under test.
+package replaytest
+
+import (
+	"testing"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/replay"
+)
+
+// Alloc allocates without a pool.
+func Alloc(size int) []byte {
+	return make([]byte, size)
+}
+
+// Int64 returns the bytes of an Int64Kind token.
+func Int64(i int64) []byte {
+	return cast.FromInt64(i, Alloc)
+}
+
+// Encoder is a replay.Encoder that fails the test, instead of returning errors.
+type Encoder struct {
+	t   testing.TB
+	enc replay.Encoder
+}
+
+func (e *Encoder) Enter(typ jsonschema.JSONSchemaType) {
+	e.enc.Enter(typ)
+}
+
+func (e *Encoder) Field(kind parse.Kind, value []byte) {
+	e.enc.Field(kind, value)
+}
+
+func (e *Encoder) Value(kind parse.Kind, value []byte) {
+	e.enc.Value(kind, value)
+}
+
+func (e *Encoder) Leave() {
+	e.t.Helper()
+	if err := e.enc.Leave(); err != nil {
+		e.t.Fatal(err)
+	}
+}
+
+// Encode returns the token stream that build encodes.
+func Encode(t testing.TB, build func(enc *Encoder)) []byte {
+	t.Helper()
+	e := &Encoder{t: t}
+	build(e)
+	buf, err := e.enc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+// NewParser returns a replay parser that is initialized with the token stream that build encodes.
+func NewParser(t testing.TB, build func(enc *Encoder)) replay.Parser {
+	t.Helper()
+	p := replay.NewParser()
+	p.Init(Encode(t, build))
+	return p
+}
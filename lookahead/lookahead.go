@@ -0,0 +1,307 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package lookahead wraps a parse.Parser to allow peeking at the next hints and tokens, without consuming them.
+package lookahead
+
+import (
+	"io"
+
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// Parser is a parser that can peek at the next hint and token.
+type Parser interface {
+	parse.Parser
+	jsonschema.JSONSchemaAble
+	Reset()
+	// Peek returns the hint that the next call to Next will return, without consuming it.
+	Peek() (parse.Hint, error)
+	// PeekToken returns the token that Token will return after the next call to Next.
+	// The returned bytes are only valid until the next call to Next or Skip, after the peeked token was consumed.
+	PeekToken() (parse.Kind, []byte, error)
+}
+
+// BoundedParser is a parser that can peek at the next k hints and tokens.
+type BoundedParser interface {
+	Parser
+	// PeekAt returns the hint that the (i+1)th call to Next will return, where 0 <= i < k.
+	PeekAt(i int) (parse.Hint, error)
+	// PeekTokenAt returns the token of the hint returned by PeekAt(i).
+	PeekTokenAt(i int) (parse.Kind, []byte, error)
+}
+
+type event struct {
+	hint     parse.Hint
+	err      error
+	kind     parse.Kind
+	value    []byte
+	tokenErr error
+	typ      jsonschema.JSONSchemaType
+}
+
+type parser struct {
+	p    parse.Parser
+	able jsonschema.JSONSchemaAble
+	// cur is the current event.
+	cur event
+	// live is true if the current event is the current event of the wrapped parser,
+	// which means that its token has not been copied yet.
+	live bool
+	// buf is a ring buffer of peeked events.
+	buf  []event
+	head int
+	n    int
+	// depth is the number of objects and arrays that are currently entered.
+	depth int
+}
+
+// NewParser returns a parser that can peek one hint and token ahead.
+// If p does not implement jsonschema.JSONSchemaAble, then JSONSchemaType is inferred by peeking after an EnterHint:
+// a FieldHint means an object and a ValueHint or EnterHint means an array.
+func NewParser(p parse.Parser) Parser {
+	return NewBoundedParser(p, 1)
+}
+
+// NewBoundedParser returns a parser that can peek k hints and tokens ahead.
+// Peeked tokens are copied into buffers that are reused, so peeking does not allocate once the buffers have grown.
+// Skip is passed through to p, unless there are peeked events to skip over,
+// in which case it is emulated by calling Next, until the buffered events are consumed.
+func NewBoundedParser(p parse.Parser, k int) BoundedParser {
+	if k < 1 {
+		k = 1
+	}
+	able, _ := p.(jsonschema.JSONSchemaAble)
+	return &parser{
+		p:    p,
+		able: able,
+		buf:  make([]event, k),
+	}
+}
+
+func (p *parser) Reset() {
+	if r, ok := p.p.(interface{ Reset() }); ok {
+		r.Reset()
+	}
+	p.cur.hint = parse.UnknownHint
+	p.live = false
+	p.head = 0
+	p.n = 0
+	p.depth = 0
+}
+
+// snapshot copies the current event of the wrapped parser, before the wrapped parser is moved forward.
+func (p *parser) snapshot() {
+	if !p.live {
+		return
+	}
+	p.live = false
+	switch p.cur.hint {
+	case parse.FieldHint, parse.ValueHint:
+		kind, value, err := p.p.Token()
+		p.cur.kind = kind
+		p.cur.value = append(p.cur.value[:0], value...)
+		p.cur.tokenErr = err
+	case parse.EnterHint:
+		if p.able != nil {
+			p.cur.typ = p.able.JSONSchemaType()
+		}
+	}
+}
+
+// read reads the next event of the wrapped parser into e.
+func (p *parser) read(e *event) {
+	e.hint, e.err = p.p.Next()
+	e.kind = parse.UnknownKind
+	e.value = e.value[:0]
+	e.tokenErr = nil
+	e.typ = jsonschema.JSONSchemaTypeUnknown
+	if e.err != nil {
+		return
+	}
+	switch e.hint {
+	case parse.FieldHint, parse.ValueHint:
+		kind, value, err := p.p.Token()
+		e.kind = kind
+		e.value = append(e.value, value...)
+		e.tokenErr = err
+	case parse.EnterHint:
+		if p.able != nil {
+			e.typ = p.able.JSONSchemaType()
+		}
+	}
+}
+
+func (p *parser) PeekAt(i int) (parse.Hint, error) {
+	if i < 0 || i >= len(p.buf) {
+		return parse.UnknownHint, errTooFar
+	}
+	p.snapshot()
+	for p.n <= i {
+		if p.n > 0 && p.buf[(p.head+p.n-1)%len(p.buf)].err != nil {
+			// Do not read past an error.
+			i = p.n - 1
+			break
+		}
+		p.read(&p.buf[(p.head+p.n)%len(p.buf)])
+		p.n++
+	}
+	e := &p.buf[(p.head+i)%len(p.buf)]
+	if e.err != nil {
+		return parse.UnknownHint, e.err
+	}
+	return e.hint, nil
+}
+
+func (p *parser) PeekTokenAt(i int) (parse.Kind, []byte, error) {
+	if _, err := p.PeekAt(i); err != nil {
+		return parse.UnknownKind, nil, err
+	}
+	e := &p.buf[(p.head+i)%len(p.buf)]
+	switch e.hint {
+	case parse.FieldHint, parse.ValueHint:
+		return e.kind, e.value, e.tokenErr
+	}
+	return parse.UnknownKind, nil, nil
+}
+
+func (p *parser) Peek() (parse.Hint, error) {
+	return p.PeekAt(0)
+}
+
+func (p *parser) PeekToken() (parse.Kind, []byte, error) {
+	return p.PeekTokenAt(0)
+}
+
+func (p *parser) Next() (parse.Hint, error) {
+	if p.n > 0 {
+		// Swap the buffers, so that the current value's buffer can be reused to peek.
+		e := &p.buf[p.head]
+		value := p.cur.value
+		p.cur = *e
+		e.value = value
+		p.head = (p.head + 1) % len(p.buf)
+		p.n--
+		p.live = false
+	} else {
+		p.cur.hint, p.cur.err = p.p.Next()
+		p.live = true
+	}
+	if p.cur.err != nil {
+		p.live = false
+		return parse.UnknownHint, p.cur.err
+	}
+	switch p.cur.hint {
+	case parse.EnterHint:
+		p.depth++
+	case parse.LeaveHint:
+		p.depth--
+	}
+	return p.cur.hint, nil
+}
+
+func (p *parser) Skip() error {
+	var err error
+	switch p.cur.hint {
+	case parse.EnterHint:
+		// Skip the whole object or array.
+		err = p.leave(p.depth - 1)
+	case parse.FieldHint:
+		// Skip the field's value.
+		if p.n == 0 && p.live {
+			err = p.p.Skip()
+		} else {
+			var h parse.Hint
+			h, err = p.Next()
+			if err == nil && h == parse.EnterHint {
+				err = p.leave(p.depth - 1)
+			}
+		}
+	case parse.ValueHint:
+		// Skip the rest of the object or array.
+		if p.depth > 0 {
+			err = p.leave(p.depth - 1)
+		}
+	default:
+		_, err = p.Next()
+		return err
+	}
+	p.cur.hint = parse.UnknownHint
+	p.live = false
+	return err
+}
+
+// leave calls Next until the depth is reduced to the given depth.
+// Once the peeked events are consumed, the wrapped parser's Skip is used.
+func (p *parser) leave(depth int) error {
+	for p.depth > depth {
+		if p.n == 0 && p.live {
+			switch p.cur.hint {
+			case parse.EnterHint, parse.ValueHint:
+				// Both skip to the end of the current object or array.
+				if err := p.p.Skip(); err != nil {
+					return err
+				}
+				p.depth--
+				p.cur.hint = parse.UnknownHint
+				continue
+			}
+		}
+		if _, err := p.Next(); err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) Token() (parse.Kind, []byte, error) {
+	if p.live {
+		return p.p.Token()
+	}
+	switch p.cur.hint {
+	case parse.FieldHint, parse.ValueHint:
+		return p.cur.kind, p.cur.value, p.cur.tokenErr
+	}
+	return parse.UnknownKind, nil, nil
+}
+
+// JSONSchemaType returns the type of the object or array that was just entered.
+// If the wrapped parser does not implement jsonschema.JSONSchemaAble, then the type is inferred by peeking at the next hint,
+// which means the type of an empty object or array is unknown.
+func (p *parser) JSONSchemaType() jsonschema.JSONSchemaType {
+	if p.cur.hint != parse.EnterHint {
+		return jsonschema.JSONSchemaTypeUnknown
+	}
+	if p.able != nil {
+		if p.live {
+			return p.able.JSONSchemaType()
+		}
+		return p.cur.typ
+	}
+	h, err := p.Peek()
+	if err != nil {
+		return jsonschema.JSONSchemaTypeUnknown
+	}
+	switch h {
+	case parse.FieldHint:
+		return jsonschema.JSONSchemaTypeObject
+	case parse.ValueHint, parse.EnterHint:
+		return jsonschema.JSONSchemaTypeArray
+	}
+	return jsonschema.JSONSchemaTypeUnknown
+}
@@ -0,0 +1,232 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package lookahead
+
+import (
+	"math/rand"
+	"testing"
+
+	"katydid.org.za/go/parser-go/expect"
+	"katydid.org.za/go/parser-go/hedge"
+	"katydid.org.za/go/parser-go/internal/replaytest"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/replay"
+	"katydid.org.za/go/parser-go/tag"
+)
+
+// encode returns a token stream for `{"a": [1, {"b": true}, []], "c": "d"}`.
+func encode(t *testing.T) []byte {
+	t.Helper()
+	return replaytest.Encode(t, func(enc *replaytest.Encoder) {
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("a"))
+		enc.Enter(jsonschema.JSONSchemaTypeArray)
+		enc.Value(parse.Int64Kind, replaytest.Int64(1))
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("b"))
+		enc.Value(parse.TrueKind, nil)
+		enc.Leave()
+		enc.Enter(jsonschema.JSONSchemaTypeArray)
+		enc.Leave()
+		enc.Leave()
+		enc.Field(parse.StringKind, []byte("c"))
+		enc.Value(parse.StringKind, []byte("d"))
+		enc.Leave()
+	})
+}
+
+func newReplay(t *testing.T) replay.Parser {
+	t.Helper()
+	p := replay.NewParser()
+	p.Init(encode(t))
+	return p
+}
+
+func expectPeek(t *testing.T, p Parser, want parse.Hint) {
+	t.Helper()
+	got, err := p.Peek()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("want peek %c, but got %c", want, got)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	p := NewParser(newReplay(t))
+	expectPeek(t, p, parse.EnterHint)
+	expectPeek(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.EnterHint)
+	expectPeek(t, p, parse.FieldHint)
+	kind, value, err := p.PeekToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != parse.StringKind || string(value) != "a" {
+		t.Fatalf("want peeked token a, but got %c %q", kind, value)
+	}
+	if typ := p.JSONSchemaType(); typ != jsonschema.JSONSchemaTypeObject {
+		t.Fatalf("want object, but got %c", typ)
+	}
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.EnterHint)
+	expectPeek(t, p, parse.ValueHint)
+	// Skip over the array, while the first element is peeked.
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expectPeek(t, p, parse.ValueHint)
+	// The current token is still available after peeking.
+	expect.String(t, p, "c")
+	expect.NoErr(t, p.Skip)
+	expectPeek(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	if _, err := p.Peek(); err == nil {
+		t.Fatal("expected EOF")
+	}
+}
+
+func TestPeekAt(t *testing.T) {
+	p := NewBoundedParser(newReplay(t), 3)
+	want := []parse.Hint{parse.EnterHint, parse.FieldHint, parse.EnterHint}
+	for i := range want {
+		got, err := p.PeekAt(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want[i] {
+			t.Fatalf("want peek %c at %d, but got %c", want[i], i, got)
+		}
+	}
+	if _, err := p.PeekAt(3); err == nil {
+		t.Fatal("expected error for peeking past the buffer")
+	}
+	kind, value, err := p.PeekTokenAt(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != parse.StringKind || string(value) != "a" {
+		t.Fatalf("want peeked token a, but got %c %q", kind, value)
+	}
+	for _, h := range want {
+		expect.Hint(t, p, h)
+	}
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+}
+
+// noSchema hides the JSONSchemaType method of the parser.
+type noSchema struct {
+	p replay.Parser
+}
+
+func (n noSchema) Next() (parse.Hint, error)          { return n.p.Next() }
+func (n noSchema) Skip() error                        { return n.p.Skip() }
+func (n noSchema) Token() (parse.Kind, []byte, error) { return n.p.Token() }
+func (n noSchema) Reset()                             { n.p.Reset() }
+
+func expectType(t *testing.T, p Parser, want jsonschema.JSONSchemaType) {
+	t.Helper()
+	if got := p.JSONSchemaType(); got != want {
+		t.Fatalf("want type %c, but got %c", want, got)
+	}
+}
+
+func TestInferJSONSchemaType(t *testing.T) {
+	p := NewParser(noSchema{newReplay(t)})
+	expect.Hint(t, p, parse.EnterHint)
+	expectType(t, p, jsonschema.JSONSchemaTypeObject)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Hint(t, p, parse.EnterHint)
+	expectType(t, p, jsonschema.JSONSchemaTypeArray)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Hint(t, p, parse.EnterHint)
+	expectType(t, p, jsonschema.JSONSchemaTypeObject)
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.EnterHint)
+	// The type of an empty array cannot be inferred.
+	expectType(t, p, jsonschema.JSONSchemaTypeUnknown)
+}
+
+// randomPeeker peeks at a random number of hints before every call to Next and Skip.
+type randomPeeker struct {
+	BoundedParser
+	r *rand.Rand
+	k int
+}
+
+func (p *randomPeeker) peek() {
+	p.PeekAt(p.r.Intn(p.k))
+	p.PeekTokenAt(p.r.Intn(p.k))
+}
+
+func (p *randomPeeker) Next() (parse.Hint, error) {
+	p.peek()
+	return p.BoundedParser.Next()
+}
+
+func (p *randomPeeker) Skip() error {
+	p.peek()
+	return p.BoundedParser.Skip()
+}
+
+func TestRandomPeek(t *testing.T) {
+	buf := encode(t)
+	for seed := int64(0); seed < 500; seed++ {
+		want, err := hedge.RandomParseInto(newReplay(t), rand.New(rand.NewSource(seed)), 10, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := replay.NewParser()
+		r.Init(buf)
+		k := 1 + int(seed%4)
+		p := &randomPeeker{NewBoundedParser(r, k), rand.New(rand.NewSource(-seed)), k}
+		got, err := hedge.RandomParseInto(p, rand.New(rand.NewSource(seed)), 10, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("seed %d: want %v, but got %v", seed, want, got)
+		}
+	}
+}
+
+func TestTagger(t *testing.T) {
+	buf := replaytest.Encode(t, func(enc *replaytest.Encoder) {
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("a"))
+		enc.Enter(jsonschema.JSONSchemaTypeArray)
+		enc.Value(parse.Int64Kind, replaytest.Int64(1))
+		enc.Leave()
+		enc.Leave()
+	})
+	r := replay.NewParser()
+	r.Init(buf)
+	want, err := hedge.ParseInto(tag.NewTagger(r, tag.WithTags()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Init(buf)
+	got, err := hedge.ParseInto(tag.NewTagger(NewParser(noSchema{r}), tag.WithTags()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
@@ -0,0 +1,32 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+// Event is a token that is returned by NextN.
+type Event struct {
+	Hint Hint
+	// Kind and Value are only set for a FieldHint or a ValueHint, see Token.
+	Kind  Kind
+	Value []byte
+}
+
+// NextNAble is an extra method for a Parser that returns multiple tokens at a time.
+// This saves the interface calls to Next and Token for every token.
+type NextNAble interface {
+	// NextN fills events with up to len(events) tokens and returns the number of events that were filled.
+	// An error, for example io.EOF, is only returned if fewer than len(events) events were filled.
+	// The events' values are only valid until the next call to NextN.
+	NextN(events []Event) (int, error)
+}
@@ -0,0 +1,152 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package datetime parses, normalizes and validates the text of parse.DateTimeKind tokens
+// and converts between parse.DateTimeKind and parse.NanosecondsKind tokens.
+package datetime
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// layouts are the RFC 3339 and ISO 8601 variants that are accepted by Parse, after the separator has been normalized.
+// Fractional seconds are always optional and can be separated with a dot or a comma.
+// Times without a time zone are interpreted as UTC.
+var layouts = []string{
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05Z07",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04Z0700",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"20060102T150405Z0700",
+	"20060102T150405Z07",
+	"20060102T150405",
+	"20060102T1504Z0700",
+	"20060102",
+}
+
+var errNotNanoseconds = errors.New("time is out of the range of nanoseconds since the Unix epoch")
+
+// Parse parses DateTime text, for example `2006-01-02T15:04:05.999Z` or `20060102T150405+0200`.
+// Both the RFC 3339 and the ISO 8601 extended and basic formats are accepted,
+// with a `T`, `t` or space between the date and the time.
+func Parse(text []byte) (time.Time, error) {
+	s := normalizeText(text)
+	var firstErr error
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+// normalizeText replaces the alternative separators, which time.Parse does not accept, with their layout equivalents.
+func normalizeText(text []byte) string {
+	buf := []byte(string(text))
+	for i, c := range buf {
+		switch c {
+		case 't', ' ':
+			// Only the separator between the extended or basic date and the time.
+			if i == 10 || i == 8 {
+				buf[i] = 'T'
+			}
+		case 'z':
+			if i == len(buf)-1 {
+				buf[i] = 'Z'
+			}
+		}
+	}
+	return string(buf)
+}
+
+// Format returns the canonical text of the time, which is RFC 3339 in UTC with the minimal fractional seconds,
+// for example `2006-01-02T13:04:05.5Z`.
+// Equal times have equal canonical text, so it can be used for hashing.
+func Format(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// Normalize parses DateTime text and returns its canonical text, see Format.
+func Normalize(text []byte) (string, error) {
+	t, err := Parse(text)
+	if err != nil {
+		return "", err
+	}
+	return Format(t), nil
+}
+
+var (
+	minNanoseconds = time.Unix(0, math.MinInt64)
+	maxNanoseconds = time.Unix(0, math.MaxInt64)
+)
+
+// ToNanoseconds returns the nanoseconds since the Unix epoch of the time,
+// which is an error if the time is not between the years 1677 and 2262.
+func ToNanoseconds(t time.Time) (int64, error) {
+	if t.Before(minNanoseconds) || t.After(maxNanoseconds) {
+		return 0, errNotNanoseconds
+	}
+	return t.UnixNano(), nil
+}
+
+// FromNanoseconds returns the time in UTC of the nanoseconds since the Unix epoch.
+func FromNanoseconds(ns int64) time.Time {
+	return time.Unix(0, ns).UTC()
+}
+
+// DateTimeToNanoseconds converts the text of a DateTimeKind token to the value of a NanosecondsKind token.
+func DateTimeToNanoseconds(text []byte) (int64, error) {
+	t, err := Parse(text)
+	if err != nil {
+		return 0, err
+	}
+	return ToNanoseconds(t)
+}
+
+// NanosecondsToDateTime converts the value of a NanosecondsKind token to the canonical text of a DateTimeKind token.
+func NanosecondsToDateTime(ns int64) string {
+	return Format(FromNanoseconds(ns))
+}
+
+// FromToken returns the time of a DateTimeKind or NanosecondsKind token.
+func FromToken(p parse.Token) (time.Time, error) {
+	kind, value, err := p.Token()
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch kind {
+	case parse.DateTimeKind:
+		return Parse(value)
+	case parse.NanosecondsKind:
+		ns, err := cast.ToInt64Checked(value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return FromNanoseconds(ns), nil
+	}
+	return time.Time{}, errors.New("cannot convert " + kind.String() + " to a time")
+}
@@ -0,0 +1,183 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package datetime
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"katydid.org.za/go/parser-go/expect"
+	"katydid.org.za/go/parser-go/internal/replaytest"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"2006-01-02T15:04:05Z":             "2006-01-02T15:04:05Z",
+		"2006-01-02t15:04:05.120z":         "2006-01-02T15:04:05.12Z",
+		"2006-01-02 15:04:05,5+02:00":      "2006-01-02T13:04:05.5Z",
+		"2006-01-02T15:04:05-0130":         "2006-01-02T16:34:05Z",
+		"2006-01-02T15:04:05+02":           "2006-01-02T13:04:05Z",
+		"2006-01-02T15:04:05":              "2006-01-02T15:04:05Z",
+		"2006-01-02T15:04Z":                "2006-01-02T15:04:00Z",
+		"2006-01-02":                       "2006-01-02T00:00:00Z",
+		"20060102T150405Z":                 "2006-01-02T15:04:05Z",
+		"20060102T150405.25+0200":          "2006-01-02T13:04:05.25Z",
+		"20060102":                         "2006-01-02T00:00:00Z",
+		"2006-01-02T15:04:05.123456789Z":   "2006-01-02T15:04:05.123456789Z",
+		"2006-01-02T00:00:00.000000+00:00": "2006-01-02T00:00:00Z",
+	}
+	for in, want := range tests {
+		got, err := Normalize([]byte(in))
+		if err != nil {
+			t.Errorf("Normalize(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestParseErr(t *testing.T) {
+	for _, in := range []string{"", "nope", "2006-13-02", "2006-01-32", "2006-01-02T25:00:00Z", "2006-01-02T15:04:05Zjunk", "2006/01/02"} {
+		if _, err := Parse([]byte(in)); err == nil {
+			t.Errorf("Parse(%q): expected error", in)
+		}
+	}
+}
+
+func TestNanoseconds(t *testing.T) {
+	ns, err := DateTimeToNanoseconds([]byte("2006-01-02T15:04:05.5+02:00"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2006, 1, 2, 13, 4, 5, 5e8, time.UTC).UnixNano()
+	if ns != want {
+		t.Fatalf("want %d, but got %d", want, ns)
+	}
+	if got := NanosecondsToDateTime(ns); got != "2006-01-02T13:04:05.5Z" {
+		t.Fatalf("got %q", got)
+	}
+	if got := NanosecondsToDateTime(math.MinInt64); got != "1677-09-21T00:12:43.145224192Z" {
+		t.Fatalf("got %q", got)
+	}
+	if _, err := DateTimeToNanoseconds([]byte("3000-01-01")); err == nil {
+		t.Fatal("expected out of range error")
+	}
+}
+
+func newParser(t *testing.T, start string) parse.Parser {
+	t.Helper()
+	// {"events": [{"start": "2006-01-02T15:04:05+02:00"}, {"start": start}], "a/b": 0ns}
+	return replaytest.NewParser(t, func(enc *replaytest.Encoder) {
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("events"))
+		enc.Enter(jsonschema.JSONSchemaTypeArray)
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("start"))
+		enc.Value(parse.DateTimeKind, []byte("2006-01-02T15:04:05+02:00"))
+		enc.Leave()
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("start"))
+		enc.Value(parse.DateTimeKind, []byte(start))
+		enc.Leave()
+		enc.Leave()
+		enc.Field(parse.StringKind, []byte("a/b"))
+		enc.Value(parse.NanosecondsKind, replaytest.Int64(0))
+		enc.Leave()
+	})
+}
+
+// walk calls Next until EOF or an error, and returns the hint that was returned with the error.
+func walk(p parse.Parser) (parse.Hint, error) {
+	for {
+		if h, err := p.Next(); err != nil {
+			if err == io.EOF {
+				return h, nil
+			}
+			return h, err
+		}
+	}
+}
+
+func TestParserValid(t *testing.T) {
+	p := NewParser(newParser(t, "2006-01-02"))
+	if _, err := walk(p); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParserInvalid(t *testing.T) {
+	p := NewParser(newParser(t, "2006-01-02T15:04:05+25:00"))
+	hint, err := walk(p)
+	if hint != parse.UnknownHint {
+		t.Fatalf("want an unknown hint with the error, but got %c", hint)
+	}
+	var dateErr *Error
+	if !errors.As(err, &dateErr) {
+		t.Fatalf("want a datetime Error, but got %v", err)
+	}
+	if dateErr.Path != "/events/1/start" {
+		t.Fatalf("want path /events/1/start, but got %q", dateErr.Path)
+	}
+}
+
+func TestParserSkip(t *testing.T) {
+	p := NewParser(newParser(t, "invalid"))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	// Skip the events, which includes the invalid DateTime.
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Hint(t, p, parse.ValueHint)
+	got, err := FromToken(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(time.Unix(0, 0)) {
+		t.Fatalf("want the Unix epoch, but got %v", got)
+	}
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestParserCanonical(t *testing.T) {
+	p := NewParser(newParser(t, "2006-01-02"), WithCanonical())
+	var got []string
+	for {
+		_, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		kind, value, err := p.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind == parse.DateTimeKind {
+			got = append(got, string(value))
+		}
+	}
+	if len(got) != 2 || got[0] != "2006-01-02T13:04:05Z" || got[1] != "2006-01-02T00:00:00Z" {
+		t.Fatalf("want canonical DateTimes, but got %v", got)
+	}
+}
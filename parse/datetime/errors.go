@@ -0,0 +1,33 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package datetime
+
+import "fmt"
+
+// Error is returned by the validating Parser, when a DateTimeKind token cannot be parsed.
+type Error struct {
+	// Path is the JSON Pointer of the token, for example `/events/0/start`.
+	Path  string
+	Value string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid DateTime %q at %q: %v", e.Value, e.Path, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
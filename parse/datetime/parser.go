@@ -0,0 +1,208 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package datetime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// Parser is a parser that validates the DateTimeKind tokens of the parser that it wraps.
+type Parser interface {
+	parse.Parser
+	jsonschema.JSONSchemaAble
+	Reset()
+}
+
+type parser struct {
+	p         parse.Parser
+	canonical bool
+	hint      parse.Hint
+	// frames is a stack of the objects and arrays that have been entered.
+	frames []frame
+	// field is the name of the last field.
+	field []byte
+	// value is the canonical text of the current DateTimeKind token, if canonical is set.
+	value []byte
+}
+
+type frame struct {
+	// segment is the path segment of the object or array.
+	segment string
+	// object is true once a field has been seen.
+	object bool
+	// index is the index of the next element of an array.
+	index int
+}
+
+// NewParser returns a parser that returns an *Error from Next, if p returns a DateTimeKind token that cannot be parsed.
+func NewParser(p parse.Parser, opts ...Option) Parser {
+	v := &parser{
+		p:      p,
+		frames: make([]frame, 0, 10),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+func (v *parser) Next() (parse.Hint, error) {
+	h, err := v.p.Next()
+	if err != nil {
+		return parse.UnknownHint, err
+	}
+	v.hint = h
+	v.value = v.value[:0]
+	switch h {
+	case parse.EnterHint:
+		v.frames = append(v.frames, frame{segment: v.segmentOf(v.advance())})
+	case parse.LeaveHint:
+		if len(v.frames) > 0 {
+			v.frames = v.frames[:len(v.frames)-1]
+		}
+	case parse.FieldHint:
+		if len(v.frames) > 0 {
+			v.frames[len(v.frames)-1].object = true
+		}
+		kind, name, err := v.p.Token()
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		v.field = append(v.field[:0], name...)
+		if kind == parse.DateTimeKind {
+			if err := v.validate(name, -1); err != nil {
+				return parse.UnknownHint, err
+			}
+		}
+	case parse.ValueHint:
+		index := v.advance()
+		kind, value, err := v.p.Token()
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		if kind == parse.DateTimeKind {
+			if err := v.validate(value, index); err != nil {
+				return parse.UnknownHint, err
+			}
+		}
+	}
+	return h, nil
+}
+
+// advance returns the index of the element that was just returned and moves onto the next element, if in an array,
+// otherwise it returns -1, which means that the path segment is the last field name.
+func (v *parser) advance() int {
+	if len(v.frames) == 0 {
+		return -1
+	}
+	top := &v.frames[len(v.frames)-1]
+	if top.object {
+		return -1
+	}
+	top.index++
+	return top.index - 1
+}
+
+// segmentOf returns the path segment of an array index or, if the index is -1, of the last field name.
+func (v *parser) segmentOf(index int) string {
+	if index < 0 {
+		return string(v.field)
+	}
+	return strconv.Itoa(index)
+}
+
+// validate parses the text of a DateTimeKind token, see segmentOf for the index.
+func (v *parser) validate(text []byte, index int) error {
+	t, err := Parse(text)
+	if err != nil {
+		return &Error{Path: v.path(v.segmentOf(index)), Value: string(text), Err: err}
+	}
+	if v.canonical {
+		v.value = t.UTC().AppendFormat(v.value[:0], time.RFC3339Nano)
+	}
+	return nil
+}
+
+// path returns the JSON Pointer of the token, which has the path segment in the current object or array.
+// The path is only built when there is an error, so that valid tokens do not allocate.
+func (v *parser) path(segment string) string {
+	if len(v.frames) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	// The root has no segment.
+	for _, f := range v.frames[1:] {
+		b.WriteByte('/')
+		b.WriteString(escape(f.segment))
+	}
+	b.WriteByte('/')
+	b.WriteString(escape(segment))
+	return b.String()
+}
+
+var escaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// escape escapes a JSON Pointer reference token, see RFC 6901.
+func escape(segment string) string {
+	return escaper.Replace(segment)
+}
+
+func (v *parser) Skip() error {
+	switch v.hint {
+	case parse.EnterHint, parse.ValueHint:
+		// Skip the object or array that was entered, or the rest of the object or array that the value is in.
+		if len(v.frames) > 0 {
+			v.frames = v.frames[:len(v.frames)-1]
+		}
+	case parse.FieldHint:
+		// Skip the field's value.
+	default:
+		_, err := v.Next()
+		return err
+	}
+	v.hint = parse.UnknownHint
+	v.value = v.value[:0]
+	return v.p.Skip()
+}
+
+// Token returns the token of the wrapped parser, or the canonical text of a DateTimeKind token, if WithCanonical is used.
+func (v *parser) Token() (parse.Kind, []byte, error) {
+	if len(v.value) > 0 {
+		return parse.DateTimeKind, v.value, nil
+	}
+	return v.p.Token()
+}
+
+func (v *parser) JSONSchemaType() jsonschema.JSONSchemaType {
+	if j, ok := v.p.(jsonschema.JSONSchemaAble); ok {
+		return j.JSONSchemaType()
+	}
+	return jsonschema.JSONSchemaTypeUnknown
+}
+
+func (v *parser) Reset() {
+	if r, ok := v.p.(interface{ Reset() }); ok {
+		r.Reset()
+	}
+	v.hint = parse.UnknownHint
+	v.frames = v.frames[:0]
+	v.field = v.field[:0]
+	v.value = v.value[:0]
+}
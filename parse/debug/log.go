@@ -71,6 +71,8 @@ func (l *l) Token() (parse.Kind, []byte, error) {
 	value, valerr := parse.GetValue(l.p)
 	if valerr != nil {
 		l.l.Printf(l.name+".Token() (%v, %v, %v) parse.GetValue() (%v)", kind, val, err, valerr)
+	} else if def, ok := parse.LookupKind(kind); ok && def.Print != nil {
+		l.l.Printf(l.name+".Token() (%v, %s, %v)", kind, def.Print(val), err)
 	} else {
 		l.l.Printf(l.name+".Token() (%v, %v, %v)", kind, value, err)
 	}
@@ -0,0 +1,69 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// CheckSpans walks through the whole parser and checks that the spans reported by the parser, see parse.SpanAble:
+//   - are within the input,
+//   - are monotonic, which means each span does not start before the previous span, and
+//   - point at the token text, which means the span contains the token's bytes for string like kinds,
+//     unless the span contains a backslash, which means the text might be escaped.
+//
+// CheckSpans is great for testing that the implemented parser reports correct spans.
+func CheckSpans(p parse.ParserWithInit, buf []byte) error {
+	p.Init(buf)
+	prev := int64(0)
+	for {
+		hint, err := p.Next()
+		if err != nil && err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		kind, val, err := p.Token()
+		if err != nil {
+			return err
+		}
+		start, end, ok := parse.GetSpan(p)
+		if !ok {
+			return fmt.Errorf("no span for %v at %d", hint, prev)
+		}
+		if start < prev {
+			return fmt.Errorf("span [%d:%d] of %v starts before previous span at %d", start, end, hint, prev)
+		}
+		if end > int64(len(buf)) {
+			return fmt.Errorf("span [%d:%d] of %v ends after input of length %d", start, end, hint, len(buf))
+		}
+		prev = start
+		if hint != parse.FieldHint && hint != parse.ValueHint {
+			continue
+		}
+		text := buf[start:end]
+		switch kind {
+		case parse.StringKind, parse.DecimalKind, parse.DateTimeKind, parse.TagKind:
+			if !bytes.Contains(text, val) && !bytes.Contains(text, []byte{'\\'}) {
+				return fmt.Errorf("span [%d:%d] of %v contains %q, which does not contain the token %q", start, end, hint, text, val)
+			}
+		}
+	}
+}
@@ -15,6 +15,8 @@
 package debug
 
 import (
+	"math/big"
+
 	"katydid.org.za/go/parser-go/cast"
 	"katydid.org.za/go/parser-go/parse"
 )
@@ -47,7 +49,7 @@ func NewIntToken(v int64) parse.Token {
 }
 
 func (v *intToken) Token() (parse.Kind, []byte, error) {
-	return parse.Float64Kind, cast.FromInt64(v.v, alloc), nil
+	return parse.Int64Kind, cast.FromInt64(v.v, alloc), nil
 }
 
 type uintToken struct {
@@ -60,7 +62,20 @@ func NewUintToken(v uint64) parse.Token {
 }
 
 func (v *uintToken) Token() (parse.Kind, []byte, error) {
-	return parse.Float64Kind, cast.FromUint64(v.v, alloc), nil
+	return parse.Uint64Kind, cast.FromUint64(v.v, alloc), nil
+}
+
+type bigIntToken struct {
+	v *big.Int
+}
+
+// NewBigIntToken wraps a native go type into a parse.Token.
+func NewBigIntToken(v *big.Int) parse.Token {
+	return &bigIntToken{v}
+}
+
+func (v *bigIntToken) Token() (parse.Kind, []byte, error) {
+	return parse.BigIntKind, cast.FromBigInt(v.v, alloc), nil
 }
 
 type boolToken struct {
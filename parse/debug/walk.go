@@ -23,6 +23,13 @@ import (
 
 // Walk walks through the whole parser in a top down manner.
 func Walk(p parse.Parser) error {
+	return WalkOf(p)
+}
+
+// WalkOf is the same as Walk, but is generic over the type of the parser.
+// When P is a concrete type, the compiler can inline calls to the parser,
+// instead of calling it through an interface.
+func WalkOf[P parse.Parser](p P) error {
 	for {
 		_, err := p.Next()
 		if err != nil && err == io.EOF {
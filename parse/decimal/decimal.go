@@ -0,0 +1,384 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package decimal parses, compares and normalizes the text of parse.DecimalKind tokens exactly.
+package decimal
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// Decimal is an arbitrary-precision decimal number, represented as digits × 10^exp.
+// The digits have no leading or trailing zeros, which means that equal numbers have equal representations.
+// The zero value is zero.
+type Decimal struct {
+	neg bool
+	// digits are ASCII digits, which are empty for zero.
+	digits []byte
+	exp    int64
+}
+
+// maxExp limits the exponent, so that calculations with it cannot overflow.
+const maxExp = 1 << 40
+
+var errSyntax = errors.New("invalid decimal syntax")
+
+var errRange = errors.New("decimal exponent is out of range")
+
+var errNotFinite = errors.New("cannot represent NaN or infinity as a decimal")
+
+// Parse parses decimal text, for example `-12.50` or `1.5e-3`.
+// The digits are copied, so the returned Decimal does not refer to text.
+func Parse(text []byte) (Decimal, error) {
+	d := Decimal{}
+	i := 0
+	if i < len(text) && (text[i] == '-' || text[i] == '+') {
+		d.neg = text[i] == '-'
+		i++
+	}
+	digits := make([]byte, 0, len(text))
+	seen := false
+	var exp int64
+	for ; i < len(text) && isDigit(text[i]); i++ {
+		digits = append(digits, text[i])
+		seen = true
+	}
+	if i < len(text) && text[i] == '.' {
+		i++
+		for ; i < len(text) && isDigit(text[i]); i++ {
+			digits = append(digits, text[i])
+			exp--
+			seen = true
+		}
+	}
+	if !seen {
+		return Decimal{}, errSyntax
+	}
+	if i < len(text) && (text[i] == 'e' || text[i] == 'E') {
+		i++
+		start := i
+		if i < len(text) && (text[i] == '-' || text[i] == '+') {
+			i++
+		}
+		if i == len(text) || !isDigit(text[i]) {
+			return Decimal{}, errSyntax
+		}
+		e, err := strconv.ParseInt(string(text[start:]), 10, 64)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return Decimal{}, errRange
+			}
+			return Decimal{}, errSyntax
+		}
+		if e > maxExp || e < -maxExp {
+			return Decimal{}, errRange
+		}
+		exp += e
+		i = len(text)
+	}
+	if i != len(text) {
+		return Decimal{}, errSyntax
+	}
+	return normalize(d.neg, digits, exp), nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// normalize strips the leading and trailing zeros of the digits.
+func normalize(neg bool, digits []byte, exp int64) Decimal {
+	for len(digits) > 0 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	for len(digits) > 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		exp++
+	}
+	if len(digits) == 0 {
+		return Decimal{}
+	}
+	return Decimal{neg: neg, digits: digits, exp: exp}
+}
+
+// FromInt64 returns the decimal of an integer.
+func FromInt64(i int64) Decimal {
+	neg := i < 0
+	u := uint64(i)
+	if neg {
+		u = -u
+	}
+	return normalize(neg, strconv.AppendUint(nil, u, 10), 0)
+}
+
+// FromFloat64 returns the exact decimal of a float, which is an error for NaN and infinities.
+func FromFloat64(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, errNotFinite
+	}
+	if f == 0 {
+		return Decimal{}, nil
+	}
+	neg := f < 0
+	// f = mant × 2^exp, where mant is an integer.
+	frac, exp := math.Frexp(math.Abs(f))
+	mant := new(big.Int).SetUint64(uint64(frac * (1 << 53)))
+	exp -= 53
+	if exp >= 0 {
+		mant.Lsh(mant, uint(exp))
+		return normalize(neg, mant.Append(nil, 10), 0), nil
+	}
+	// mant × 2^exp = mant × 5^-exp × 10^exp
+	five := new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(-exp)), nil)
+	mant.Mul(mant, five)
+	return normalize(neg, mant.Append(nil, 10), int64(exp)), nil
+}
+
+// FromToken returns the decimal of a DecimalKind, Int64Kind, Uint64Kind, BigIntKind or Float64Kind token.
+func FromToken(p parse.Token) (Decimal, error) {
+	kind, value, err := p.Token()
+	if err != nil {
+		return Decimal{}, err
+	}
+	switch kind {
+	case parse.DecimalKind, parse.BigIntKind:
+		return Parse(value)
+	case parse.Int64Kind:
+		i, err := cast.ToInt64Checked(value)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return FromInt64(i), nil
+	case parse.Uint64Kind:
+		u, err := cast.ToUint64Checked(value)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return normalize(false, strconv.AppendUint(nil, u, 10), 0), nil
+	case parse.Float64Kind:
+		f, err := cast.ToFloat64Checked(value)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return FromFloat64(f)
+	}
+	return Decimal{}, errors.New("cannot convert " + kind.String() + " to a decimal")
+}
+
+// IsZero returns whether the decimal is zero.
+func (d Decimal) IsZero() bool {
+	return len(d.digits) == 0
+}
+
+// Sign returns -1, 0 or 1, if the decimal is negative, zero or positive.
+func (d Decimal) Sign() int {
+	switch {
+	case d.IsZero():
+		return 0
+	case d.neg:
+		return -1
+	}
+	return 1
+}
+
+// adjusted returns the exponent of the most significant digit.
+func (d Decimal) adjusted() int64 {
+	return int64(len(d.digits)) + d.exp - 1
+}
+
+// Cmp compares two decimals exactly and returns -1, 0 or 1, if d is less than, equal to or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	if ds, es := d.Sign(), e.Sign(); ds != es || ds == 0 {
+		return cmp(ds, es)
+	}
+	c := d.cmpAbs(e)
+	if d.neg {
+		return -c
+	}
+	return c
+}
+
+func (d Decimal) cmpAbs(e Decimal) int {
+	if c := cmp(d.adjusted(), e.adjusted()); c != 0 {
+		return c
+	}
+	// The most significant digits have the same exponent, so the digits can be compared in order.
+	for i := 0; i < len(d.digits) && i < len(e.digits); i++ {
+		if c := cmp(d.digits[i], e.digits[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp(len(d.digits), len(e.digits))
+}
+
+func cmp[T int | int64 | byte](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Equal returns whether two decimals are equal.
+func (d Decimal) Equal(e Decimal) bool {
+	return d.Cmp(e) == 0
+}
+
+// CmpInt64 compares the decimal to an integer exactly.
+func (d Decimal) CmpInt64(i int64) int {
+	return d.Cmp(FromInt64(i))
+}
+
+// CmpFloat64 compares the decimal to a float exactly.
+// The decimal is less than positive infinity and greater than negative infinity.
+// NaN cannot be compared, in which case false is returned.
+func (d Decimal) CmpFloat64(f float64) (int, bool) {
+	switch {
+	case math.IsNaN(f):
+		return 0, false
+	case math.IsInf(f, 1):
+		return -1, true
+	case math.IsInf(f, -1):
+		return 1, true
+	}
+	e, _ := FromFloat64(f)
+	return d.Cmp(e), true
+}
+
+// Int64 returns the decimal as an integer, if it is an integer that fits into an int64.
+func (d Decimal) Int64() (int64, bool) {
+	if d.IsZero() {
+		return 0, true
+	}
+	if d.exp < 0 || d.adjusted() > 18 {
+		return 0, false
+	}
+	u, err := strconv.ParseUint(string(d.digits), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	for i := int64(0); i < d.exp; i++ {
+		u *= 10
+	}
+	if d.neg {
+		if u > 1<<63 {
+			return 0, false
+		}
+		return int64(-u), true
+	}
+	if u > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(u), true
+}
+
+// Float64 returns the float that is nearest to the decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.scientific(), 64)
+	return f
+}
+
+// Rat returns the decimal as a rational number.
+// The memory used is proportional to the exponent, so the exponent of untrusted input should be checked first.
+func (d Decimal) Rat() *big.Rat {
+	r := new(big.Rat)
+	if d.IsZero() {
+		return r
+	}
+	n, _ := new(big.Int).SetString(string(d.digits), 10)
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(abs(d.exp)), nil)
+	if d.exp >= 0 {
+		r.SetInt(n.Mul(n, pow))
+	} else {
+		r.SetFrac(n, pow)
+	}
+	if d.neg {
+		r.Neg(r)
+	}
+	return r
+}
+
+func abs(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// scientific returns the decimal as `-d.ddde±x`.
+func (d Decimal) scientific() string {
+	if d.IsZero() {
+		return "0"
+	}
+	buf := make([]byte, 0, len(d.digits)+24)
+	if d.neg {
+		buf = append(buf, '-')
+	}
+	buf = append(buf, d.digits[0])
+	if len(d.digits) > 1 {
+		buf = append(buf, '.')
+		buf = append(buf, d.digits[1:]...)
+	}
+	buf = append(buf, 'e')
+	adjusted := d.adjusted()
+	if adjusted >= 0 {
+		buf = append(buf, '+')
+	}
+	buf = strconv.AppendInt(buf, adjusted, 10)
+	return string(buf)
+}
+
+// String returns the canonical text of the decimal, which is the same for equal decimals, so it can be used for hashing.
+// Plain notation, like `-0.015` or `1200`, is used when the exponent of the most significant digit is between -7 and 21,
+// otherwise scientific notation, like `1.5e+30`, is used.
+func (d Decimal) String() string {
+	if d.IsZero() {
+		return "0"
+	}
+	adjusted := d.adjusted()
+	if adjusted < -7 || adjusted > 21 {
+		return d.scientific()
+	}
+	buf := make([]byte, 0, len(d.digits)+32)
+	if d.neg {
+		buf = append(buf, '-')
+	}
+	switch {
+	case d.exp >= 0:
+		buf = append(buf, d.digits...)
+		for i := int64(0); i < d.exp; i++ {
+			buf = append(buf, '0')
+		}
+	case adjusted >= 0:
+		point := int(adjusted) + 1
+		buf = append(buf, d.digits[:point]...)
+		buf = append(buf, '.')
+		buf = append(buf, d.digits[point:]...)
+	default:
+		buf = append(buf, '0', '.')
+		for i := int64(0); i < -adjusted-1; i++ {
+			buf = append(buf, '0')
+		}
+		buf = append(buf, d.digits...)
+	}
+	return string(buf)
+}
@@ -0,0 +1,185 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) Decimal {
+	t.Helper()
+	d, err := Parse([]byte(s))
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return d
+}
+
+func TestString(t *testing.T) {
+	tests := map[string]string{
+		"0":          "0",
+		"-0.000":     "0",
+		"+1":         "1",
+		"1.50":       "1.5",
+		"001.2300":   "1.23",
+		"1200":       "1200",
+		"1.2e3":      "1200",
+		"12e-1":      "1.2",
+		".5":         "0.5",
+		"5.":         "5",
+		"-0.015":     "-0.015",
+		"1E+2":       "100",
+		"1e30":       "1e+30",
+		"-1.50e-20":  "-1.5e-20",
+		"0.00000001": "1e-8",
+		"0.0000001":  "0.0000001",
+	}
+	for in, want := range tests {
+		if got := mustParse(t, in).String(); got != want {
+			t.Errorf("Parse(%q).String() = %q, want %q", in, got, want)
+		}
+		// The canonical text parses to the same decimal.
+		if got := mustParse(t, want).String(); got != want {
+			t.Errorf("Parse(%q).String() = %q, is not canonical", want, got)
+		}
+	}
+}
+
+func TestParseErr(t *testing.T) {
+	for _, in := range []string{"", "-", ".", "e1", "1e", "1e+", "1.2.3", "1x", "--1", "1e99999999999999999999", "1e2000000000000"} {
+		if _, err := Parse([]byte(in)); err == nil {
+			t.Errorf("Parse(%q): expected error", in)
+		}
+	}
+}
+
+func TestCmp(t *testing.T) {
+	ordered := []string{"-1e30", "-100", "-1.5", "-0.001", "0", "1e-30", "0.1", "0.10001", "1", "1.000001", "10", "1e30"}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			want := cmp(i, j)
+			if got := mustParse(t, a).Cmp(mustParse(t, b)); got != want {
+				t.Errorf("Cmp(%s, %s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+	if !mustParse(t, "1.10").Equal(mustParse(t, "11e-1")) {
+		t.Fatal("expected equal")
+	}
+}
+
+func TestCmpInt64(t *testing.T) {
+	tests := []struct {
+		d    string
+		i    int64
+		want int
+	}{
+		{"9223372036854775807", math.MaxInt64, 0},
+		{"9223372036854775808", math.MaxInt64, 1},
+		{"-9223372036854775808", math.MinInt64, 0},
+		{"-9223372036854775808.5", math.MinInt64, -1},
+		{"1.5", 1, 1},
+		{"1.5", 2, -1},
+		{"100e-2", 1, 0},
+	}
+	for _, test := range tests {
+		if got := mustParse(t, test.d).CmpInt64(test.i); got != test.want {
+			t.Errorf("CmpInt64(%s, %d) = %d, want %d", test.d, test.i, got, test.want)
+		}
+	}
+}
+
+func TestCmpFloat64(t *testing.T) {
+	tests := []struct {
+		d    string
+		f    float64
+		want int
+	}{
+		{"0.5", 0.5, 0},
+		// 0.1 is not exactly representable, the float is slightly larger.
+		{"0.1", 0.1, -1},
+		{"0.1000000000000000055511151231257827021181583404541015625", 0.1, 0},
+		{"1e400", math.MaxFloat64, 1},
+		{"1e400", math.Inf(1), -1},
+		{"-1e400", math.Inf(-1), 1},
+		{"5e-324", math.SmallestNonzeroFloat64, 1},
+		{"-0", math.Copysign(0, -1), 0},
+		{"9007199254740993", 9007199254740992, 1},
+	}
+	for _, test := range tests {
+		got, ok := mustParse(t, test.d).CmpFloat64(test.f)
+		if !ok || got != test.want {
+			t.Errorf("CmpFloat64(%s, %v) = %d, %v, want %d", test.d, test.f, got, ok, test.want)
+		}
+	}
+	if _, ok := mustParse(t, "1").CmpFloat64(math.NaN()); ok {
+		t.Fatal("expected NaN to be incomparable")
+	}
+}
+
+func TestFromFloat64(t *testing.T) {
+	for _, f := range []float64{0, 1, -1, 0.1, 1.5e300, -2.5e-310, math.MaxFloat64, math.SmallestNonzeroFloat64} {
+		d, err := FromFloat64(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.Rat(); got.Cmp(new(big.Rat).SetFloat64(f)) != 0 {
+			t.Errorf("FromFloat64(%v) = %s, is not exact", f, d)
+		}
+		if got := d.Float64(); got != f {
+			t.Errorf("FromFloat64(%v).Float64() = %v", f, got)
+		}
+	}
+	if _, err := FromFloat64(math.Inf(1)); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestInt64(t *testing.T) {
+	tests := []struct {
+		d    string
+		want int64
+		ok   bool
+	}{
+		{"0", 0, true},
+		{"12e2", 1200, true},
+		{"-9223372036854775808", math.MinInt64, true},
+		{"9223372036854775808", 0, false},
+		{"1.5", 0, false},
+		{"1e19", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := mustParse(t, test.d).Int64()
+		if got != test.want || ok != test.ok {
+			t.Errorf("Int64(%s) = %d, %v, want %d, %v", test.d, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestRat(t *testing.T) {
+	tests := map[string]string{
+		"0":      "0/1",
+		"-1.25":  "-5/4",
+		"1.2e3":  "1200/1",
+		"125e-5": "1/800",
+	}
+	for in, want := range tests {
+		if got := mustParse(t, in).Rat().String(); got != want {
+			t.Errorf("Rat(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
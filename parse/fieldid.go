@@ -0,0 +1,32 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+// FieldIDAble is an extra method for a Parser that identifies fields with small integers,
+// so that matching a field is an integer comparison, instead of comparing bytes.
+// Binary formats with field numbers, like protobuf, can supply these directly.
+type FieldIDAble interface {
+	// FieldID returns the ID of the current field and whether it is known.
+	// FieldID is only valid after Next returned a FieldHint.
+	FieldID() (int, bool)
+}
+
+// GetFieldID returns the ID of the current field, if the parser implements FieldIDAble and knows it.
+func GetFieldID(p Parser) (int, bool) {
+	if f, ok := p.(FieldIDAble); ok {
+		return f.FieldID()
+	}
+	return 0, false
+}
@@ -14,6 +14,8 @@
 
 package parse
 
+import "fmt"
+
 // Kind of the token that is parsed.
 // This is represented by one for following bytes:
 // * '_': Null (Null)
@@ -22,11 +24,14 @@ package parse
 // * 'x': Bytes (Bytes)
 // * '"': String (String)
 // * '-': Int64 (Int64)
+// * '+': Uint64 (Uint64)
+// * 'Z': BigInt (String of decimal digits, with an optional sign)
 // * '.': Float64 (Float64)
 // * '/': Decimal (String)
 // * '9': Nanoseconds (Int64)
 // * 'T': DateTime ISO 8601 (String)
 // * '#': User defined Tag (String)
+// Other bytes can be used for user defined kinds, see RegisterKind.
 type Kind byte
 
 const UnknownKind = Kind(0)
@@ -71,6 +76,19 @@ func (k Kind) IsInt64() bool {
 	return k == Int64Kind
 }
 
+const Uint64Kind = Kind('+')
+
+func (k Kind) IsUint64() bool {
+	return k == Uint64Kind
+}
+
+// BigIntKind is an integer of arbitrary size, represented as decimal text, for example `-18446744073709551617`.
+const BigIntKind = Kind('Z')
+
+func (k Kind) IsBigInt() bool {
+	return k == BigIntKind
+}
+
 const Float64Kind = Kind('.')
 
 func (k Kind) IsFloat64() bool {
@@ -117,6 +135,10 @@ func (k Kind) String() string {
 		return "string"
 	case Int64Kind:
 		return "int64"
+	case Uint64Kind:
+		return "uint64"
+	case BigIntKind:
+		return "bigInt"
 	case Float64Kind:
 		return "float64"
 	case DecimalKind:
@@ -128,5 +150,18 @@ func (k Kind) String() string {
 	case TagKind:
 		return "tag"
 	}
-	panic("unreachable")
+	if def, ok := LookupKind(k); ok {
+		return def.Name
+	}
+	return fmt.Sprintf("kind(%q)", byte(k))
+}
+
+func (k Kind) isBuiltin() bool {
+	switch k {
+	case UnknownKind, NullKind, FalseKind, TrueKind, BytesKind, StringKind,
+		Int64Kind, Uint64Kind, BigIntKind, Float64Kind, DecimalKind,
+		NanosecondsKind, DateTimeKind, TagKind:
+		return true
+	}
+	return false
 }
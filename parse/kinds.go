@@ -0,0 +1,72 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"fmt"
+	"sync"
+)
+
+// KindDef defines a user defined Kind, see RegisterKind.
+type KindDef struct {
+	// Name is returned by Kind.String.
+	Name string
+	// Value decodes the bytes of a token into a Go value, which is returned by GetValue.
+	// If Value is nil, GetValue returns the bytes.
+	Value func([]byte) (any, error)
+	// Print prints the bytes of a token for Sprint and the debug loggers.
+	// If Print is nil, the Go value returned by GetValue is printed.
+	Print func([]byte) string
+}
+
+var (
+	kindsMu sync.RWMutex
+	kinds   = make(map[Kind]KindDef)
+)
+
+// RegisterKind makes a user defined Kind known to Kind.String, GetValue and Sprint.
+// RegisterKind is typically called from the init function of a package that implements a parser.
+// If RegisterKind is called twice with the same kind, with a built in kind or without a name, it panics.
+func RegisterKind(kind Kind, def KindDef) {
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+	if def.Name == "" {
+		panic("parse: RegisterKind name is empty")
+	}
+	if kind.isBuiltin() {
+		panic("parse: RegisterKind called for built in kind " + kind.String())
+	}
+	if _, dup := kinds[kind]; dup {
+		panic(fmt.Sprintf("parse: RegisterKind called twice for kind %q", byte(kind)))
+	}
+	kinds[kind] = def
+}
+
+// LookupKind returns the definition of a user defined Kind, see RegisterKind.
+func LookupKind(kind Kind) (KindDef, bool) {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	def, ok := kinds[kind]
+	return def, ok
+}
+
+// UnknownKindError is returned by GetValue for a Kind that is neither built in nor registered.
+type UnknownKindError struct {
+	Kind Kind
+}
+
+func (e *UnknownKindError) Error() string {
+	return "unknown kind " + e.Kind.String()
+}
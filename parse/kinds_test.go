@@ -0,0 +1,82 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterKind(Kind('u'), KindDef{
+		Name: "upper",
+		Value: func(bs []byte) (any, error) {
+			return strings.ToUpper(string(bs)), nil
+		},
+		Print: func(bs []byte) string {
+			return "upper:" + string(bs)
+		},
+	})
+	RegisterKind(Kind('r'), KindDef{Name: "raw"})
+}
+
+func TestRegisteredKind(t *testing.T) {
+	tok := textToken{Kind('u'), "abc"}
+	if got := Kind('u').String(); got != "upper" {
+		t.Fatalf("want upper, but got %q", got)
+	}
+	v, err := GetValue(tok)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "ABC" {
+		t.Fatalf("want ABC, but got %v", v)
+	}
+	if got := Sprint(tok); got != "upper:abc" {
+		t.Fatalf("want upper:abc, but got %q", got)
+	}
+	v, err = GetValue(textToken{Kind('r'), "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bs, ok := v.([]byte); !ok || string(bs) != "abc" {
+		t.Fatalf("want the bytes, but got %#v", v)
+	}
+}
+
+func TestUnregisteredKind(t *testing.T) {
+	tok := textToken{Kind('?'), "abc"}
+	if got := Kind('?').String(); got != "kind('?')" {
+		t.Fatalf("want kind('?'), but got %q", got)
+	}
+	_, err := GetValue(tok)
+	var kindErr *UnknownKindError
+	if !errors.As(err, &kindErr) || kindErr.Kind != Kind('?') {
+		t.Fatalf("want an UnknownKindError, but got %v", err)
+	}
+	if got := Sprint(tok); !strings.HasPrefix(got, "error:<") {
+		t.Fatalf("want an error, but got %q", got)
+	}
+}
+
+func TestRegisterBuiltinKind(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want a panic")
+		}
+	}()
+	RegisterKind(StringKind, KindDef{Name: "string"})
+}
@@ -0,0 +1,32 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+// LenAble is an extra method for a Parser that reports the number of fields or elements of an object or array up front.
+// Binary formats, like protobuf, CBOR and MessagePack, typically know this, which allows the consumer to preallocate.
+type LenAble interface {
+	// Len returns the number of fields of the object or elements of the array, that was just entered, and whether it is known.
+	// Len is only valid after Next returned an EnterHint.
+	Len() (int, bool)
+}
+
+// GetLen returns the number of fields or elements of the object or array that was just entered, if the parser implements LenAble and knows it.
+func GetLen(p Parser) (int, bool) {
+	if l, ok := p.(LenAble); ok {
+		n, ok := l.Len()
+		return n, ok && n >= 0
+	}
+	return 0, false
+}
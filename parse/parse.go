@@ -50,6 +50,7 @@ type Token interface {
 }
 
 // GetValue returns the current value.
+// The value of a user defined kind is decoded by its registered Value function and an unregistered kind returns an *UnknownKindError, see RegisterKind.
 func GetValue(p Token) (any, error) {
 	kind, val, err := p.Token()
 	if err != nil {
@@ -67,28 +68,46 @@ func GetValue(p Token) (any, error) {
 	case StringKind:
 		return cast.ToString(val), nil
 	case Int64Kind:
-		return cast.ToInt64(val), nil
+		return cast.ToInt64Checked(val)
+	case Uint64Kind:
+		return cast.ToUint64Checked(val)
+	case BigIntKind:
+		return cast.ToBigInt(val)
 	case Float64Kind:
-		return cast.ToFloat64(val), nil
+		return cast.ToFloat64Checked(val)
 	case DecimalKind:
 		return cast.ToString(val), nil
 	case NanosecondsKind:
-		return cast.ToInt64(val), nil
+		return cast.ToInt64Checked(val)
 	case DateTimeKind:
 		return cast.ToString(val), nil
 	case TagKind:
 		return cast.ToString(val), nil
 	case UnknownKind:
 		return nil, errUnknownKind
-	default:
-		panic("unreachable")
 	}
+	def, ok := LookupKind(kind)
+	if !ok {
+		return nil, &UnknownKindError{kind}
+	}
+	if def.Value == nil {
+		return val, nil
+	}
+	return def.Value(val)
 }
 
 var errUnknownKind = errors.New("unknown kind")
 
 // Sprint returns a value printed as a string.
+// User defined kinds are printed with their registered Print function, see RegisterKind.
 func Sprint(value Token) string {
+	kind, val, err := value.Token()
+	if err != nil {
+		return fmt.Sprintf("error:<%v>", err)
+	}
+	if def, ok := LookupKind(kind); ok && def.Print != nil {
+		return def.Print(val)
+	}
 	v, err := GetValue(value)
 	if err != nil {
 		return fmt.Sprintf("error:<%v>", err)
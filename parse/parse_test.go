@@ -0,0 +1,59 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"math/big"
+	"testing"
+)
+
+type shortToken struct {
+	kind Kind
+}
+
+func (s shortToken) Token() (Kind, []byte, error) {
+	return s.kind, []byte{1, 2, 3}, nil
+}
+
+func TestGetValueShortToken(t *testing.T) {
+	for _, kind := range []Kind{Int64Kind, Uint64Kind, Float64Kind, NanosecondsKind} {
+		if _, err := GetValue(shortToken{kind}); err == nil {
+			t.Fatalf("want error for a short %v token", kind)
+		}
+	}
+}
+
+type textToken struct {
+	kind Kind
+	text string
+}
+
+func (s textToken) Token() (Kind, []byte, error) {
+	return s.kind, []byte(s.text), nil
+}
+
+func TestGetValueBigInt(t *testing.T) {
+	v, err := GetValue(textToken{BigIntKind, "-18446744073709551617"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := new(big.Int).SetString("-18446744073709551617", 10)
+	if got, ok := v.(*big.Int); !ok || got.Cmp(want) != 0 {
+		t.Fatalf("want %v, but got %#v", want, v)
+	}
+	if _, err := GetValue(textToken{BigIntKind, "1.5"}); err == nil {
+		t.Fatal("want error for a BigInt token that is not an integer")
+	}
+}
@@ -0,0 +1,49 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import "errors"
+
+// RawTokenAble is an extra method for a Parser that returns the verbatim source bytes of the current token.
+// Token returns decoded bytes, for example unescaped strings, which means the original text, like `1.50` or `"ab"`, is lost.
+// RawToken allows a tool to reproduce the original text.
+type RawTokenAble interface {
+	// RawToken returns the source bytes of the current token, which should not be modified.
+	RawToken() ([]byte, error)
+}
+
+// RawSubtreeAble is an extra method for a Parser that returns the verbatim source bytes of a whole subtree.
+type RawSubtreeAble interface {
+	// RawSubtree returns the source bytes of the object or array that was entered with the last EnterHint,
+	// including its opening and closing delimiters.
+	// The parser is moved past the subtree, as if Skip was called.
+	RawSubtree() ([]byte, error)
+}
+
+var errNoRawSubtree = errors.New("parser does not implement RawSubtreeAble and no fallback encoder was provided")
+
+// RawSubtree returns the source bytes of the subtree, after Next returned an EnterHint, and moves the parser past the subtree, as if Skip was called.
+// If the parser does not implement RawSubtreeAble, then the subtree is re-encoded using the fallback encode function,
+// which should consume the rest of the subtree, up to and including its LeaveHint.
+// This is useful for building proxies that validate and forward their input unchanged.
+func RawSubtree(p Parser, encode func(Parser) ([]byte, error)) ([]byte, error) {
+	if r, ok := p.(RawSubtreeAble); ok {
+		return r.RawSubtree()
+	}
+	if encode == nil {
+		return nil, errNoRawSubtree
+	}
+	return encode(p)
+}
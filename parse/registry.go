@@ -0,0 +1,83 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"mime"
+	"strings"
+	"sync"
+)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]func() ParserWithInit)
+)
+
+// Register makes a parser available for the media type, for example "application/json".
+// Register is typically called from the init function of a package that implements a parser.
+// If Register is called twice with the same media type or if factory is nil, it panics.
+func Register(mediaType string, factory func() ParserWithInit) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	if factory == nil {
+		panic("parse: Register factory is nil")
+	}
+	mediaType = normalizeMediaType(mediaType)
+	if _, dup := factories[mediaType]; dup {
+		panic("parse: Register called twice for media type " + mediaType)
+	}
+	factories[mediaType] = factory
+}
+
+// unregister removes the parser for the media type, so that tests do not leave their parsers registered.
+func unregister(mediaType string) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	delete(factories, normalizeMediaType(mediaType))
+}
+
+// New returns a new parser for the media type, for example "application/json; charset=utf-8".
+// Parameters are ignored and structured syntax suffixes are used as a fallback,
+// for example "application/vnd.api+json" falls back to "application/json".
+// New returns nil if no parser is registered for the media type.
+func New(mediaType string) ParserWithInit {
+	// The factory is called without holding the lock, since it could register other parsers or kinds.
+	factory := lookupFactory(normalizeMediaType(mediaType))
+	if factory == nil {
+		return nil
+	}
+	return factory()
+}
+
+func lookupFactory(mediaType string) func() ParserWithInit {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	if factory, ok := factories[mediaType]; ok {
+		return factory
+	}
+	if i := strings.LastIndexByte(mediaType, '+'); i >= 0 {
+		if factory, ok := factories["application/"+mediaType[i+1:]]; ok {
+			return factory
+		}
+	}
+	return nil
+}
+
+func normalizeMediaType(mediaType string) string {
+	if parsed, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return parsed
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
@@ -0,0 +1,130 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+
+	"katydid.org.za/go/parser-go/jsonschema"
+)
+
+// SkipToAble is an extra method for a Parser that skips to a field in an object.
+// Implementations can accelerate this, for example by scanning raw bytes, instead of decoding every sibling.
+type SkipToAble interface {
+	// SkipTo skips over fields, including their values, until it finds the field with the given name.
+	// SkipTo may only be called when the next call to Next would return a FieldHint or a LeaveHint,
+	// for example directly after Next returned an EnterHint for an object.
+	// If the field is found, then the parser is positioned as if Next returned the field's FieldHint,
+	// otherwise the object's LeaveHint is consumed and false is returned.
+	SkipTo(name []byte) (bool, error)
+}
+
+// ErrNotObject is returned by SkipTo, if it is not called inside an object.
+var ErrNotObject = errors.New("expected a field or the end of an object")
+
+// SkipTo skips to the field with the given name in the current object, see SkipToAble.
+// If the parser does not implement SkipToAble, then Next, Token and Skip are called until the field is found.
+func SkipTo(p Parser, name []byte) (bool, error) {
+	if s, ok := p.(SkipToAble); ok {
+		return s.SkipTo(name)
+	}
+	for {
+		hint, err := p.Next()
+		if err != nil {
+			return false, err
+		}
+		switch hint {
+		case LeaveHint:
+			return false, nil
+		case FieldHint:
+		default:
+			return false, ErrNotObject
+		}
+		kind, value, err := p.Token()
+		if err != nil {
+			return false, err
+		}
+		if (kind == StringKind || kind == BytesKind) && bytes.Equal(value, name) {
+			return true, nil
+		}
+		// Skip the field's value.
+		if err := p.Skip(); err != nil {
+			return false, err
+		}
+	}
+}
+
+// Find finds the value at the path, starting from the root, before Next has been called.
+// Each element of the path is either a field name, for objects, or a decimal index, for arrays.
+// Arrays can only be distinguished from objects if the parser implements jsonschema.JSONSchemaAble,
+// otherwise every element is treated as a field name.
+// If the value is found, then the parser is positioned as if Next returned the value's Hint, which is returned.
+// Find returns false if the path does not exist.
+func Find(p Parser, path ...string) (Hint, bool, error) {
+	hint, err := p.Next()
+	if err != nil {
+		return UnknownHint, false, err
+	}
+	for _, name := range path {
+		if hint != EnterHint {
+			return UnknownHint, false, nil
+		}
+		if s, ok := p.(jsonschema.JSONSchemaAble); ok && s.JSONSchemaType() == jsonschema.JSONSchemaTypeArray {
+			hint, err = findIndex(p, name)
+			if err != nil || hint == UnknownHint {
+				return UnknownHint, false, err
+			}
+			continue
+		}
+		found, err := SkipTo(p, []byte(name))
+		if err != nil || !found {
+			return UnknownHint, false, err
+		}
+		hint, err = p.Next()
+		if err != nil {
+			return UnknownHint, false, err
+		}
+	}
+	return hint, true, nil
+}
+
+// findIndex finds the element at the index in the current array and returns its Hint,
+// or an UnknownHint if the array is too short or the index is not a decimal index.
+func findIndex(p Parser, index string) (Hint, error) {
+	n, err := strconv.Atoi(index)
+	if err != nil || n < 0 {
+		return UnknownHint, nil
+	}
+	for i := 0; ; i++ {
+		hint, err := p.Next()
+		if err != nil {
+			return UnknownHint, err
+		}
+		if hint == LeaveHint {
+			return UnknownHint, nil
+		}
+		if i == n {
+			return hint, nil
+		}
+		if hint == EnterHint {
+			// Skip the whole element.
+			if err := p.Skip(); err != nil {
+				return UnknownHint, err
+			}
+		}
+	}
+}
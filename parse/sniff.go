@@ -0,0 +1,208 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+const MediaTypeJSON = "application/json"
+
+const MediaTypeCBOR = "application/cbor"
+
+const MediaTypeMessagePack = "application/msgpack"
+
+const MediaTypeXML = "application/xml"
+
+const MediaTypeYAML = "application/yaml"
+
+const MediaTypeProtobuf = "application/protobuf"
+
+// Sniff returns the media type of the input, by looking at magic bytes and using heuristics.
+// The input can be the whole document or only a prefix of it.
+// Sniff returns one of the MediaType constants or an empty string if the media type is unknown.
+//
+// Text is detected as:
+//   - JSON, if it starts with '{' or '['.
+//   - XML, if it starts with '<'.
+//   - YAML, if it starts with "---", "%YAML", a "key:" or a "- " list item.
+//
+// Binary is detected as:
+//   - CBOR, if it starts with the self-describe tag or a map.
+//   - MessagePack, if it starts with a map or a 16 or 32 bit array.
+//   - Protobuf, if it is a valid sequence of protobuf fields.
+func Sniff(buf []byte) string {
+	if len(buf) == 0 {
+		return ""
+	}
+	if bytes.HasPrefix(buf, cborSelfDescribe) {
+		return MediaTypeCBOR
+	}
+	if isText(buf) {
+		return sniffText(buf)
+	}
+	// Protobuf fields with field numbers less than 16 start with a byte less than 0x80,
+	// which is never the start of a CBOR or MessagePack map.
+	if buf[0] < 0x80 {
+		if isProtobuf(buf) {
+			return MediaTypeProtobuf
+		}
+		return ""
+	}
+	switch {
+	case isCBORMap(buf[0]):
+		return MediaTypeCBOR
+	case isMessagePackContainer(buf[0]):
+		return MediaTypeMessagePack
+	case isProtobuf(buf):
+		return MediaTypeProtobuf
+	}
+	return ""
+}
+
+var utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+// cborSelfDescribe is tag 55799, which is a magic number for CBOR.
+var cborSelfDescribe = []byte{0xd9, 0xd9, 0xf7}
+
+func isText(buf []byte) bool {
+	buf = bytes.TrimPrefix(buf, utf8BOM)
+	for _, b := range buf {
+		if (b < 0x20 && b != '\t' && b != '\n' && b != '\r') || b == 0x7f {
+			return false
+		}
+	}
+	// The prefix might end in the middle of a rune.
+	for i := len(buf) - 1; i >= 0 && i >= len(buf)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(buf[i]) {
+			if !utf8.FullRune(buf[i:]) {
+				buf = buf[:i]
+			}
+			break
+		}
+	}
+	return utf8.Valid(buf)
+}
+
+func sniffText(buf []byte) string {
+	buf = bytes.TrimLeft(bytes.TrimPrefix(buf, utf8BOM), " \t\r\n")
+	if len(buf) == 0 {
+		return ""
+	}
+	switch buf[0] {
+	case '{', '[':
+		return MediaTypeJSON
+	case '<':
+		return MediaTypeXML
+	}
+	if bytes.HasPrefix(buf, []byte("---")) || bytes.HasPrefix(buf, []byte("%YAML")) {
+		return MediaTypeYAML
+	}
+	for len(buf) > 0 {
+		line := buf
+		if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+			line, buf = buf[:i], buf[i+1:]
+		} else {
+			buf = nil
+		}
+		line = bytes.TrimRight(line, " \t\r")
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		if isYAMLLine(line) {
+			return MediaTypeYAML
+		}
+		return ""
+	}
+	return ""
+}
+
+func isYAMLLine(line []byte) bool {
+	if bytes.Equal(line, []byte("-")) || bytes.HasPrefix(line, []byte("- ")) {
+		return true
+	}
+	key, _, found := bytes.Cut(line, []byte(": "))
+	if !found {
+		key, found = bytes.CutSuffix(line, []byte(":"))
+	}
+	return found && len(key) > 0 && key[0] != ' ' && key[0] != '\t'
+}
+
+func isCBORMap(b byte) bool {
+	// major type 5 with a length of up to 8 bytes or an indefinite length.
+	return (b >= 0xa0 && b <= 0xbb) || b == 0xbf
+}
+
+func isMessagePackContainer(b byte) bool {
+	// fixmap, map 16, map 32, array 16 and array 32.
+	return (b >= 0x80 && b <= 0x8f) || b == 0xde || b == 0xdf || b == 0xdc || b == 0xdd
+}
+
+// isProtobuf returns whether the input is a sequence of valid protobuf fields.
+// The last field is allowed to be cut short, since the input might only be a prefix.
+func isProtobuf(buf []byte) bool {
+	fields := 0
+	for len(buf) > 0 {
+		key, n := binary.Uvarint(buf)
+		if n == 0 {
+			return fields > 0
+		}
+		if n < 0 {
+			return false
+		}
+		buf = buf[n:]
+		fieldNumber, wireType := key>>3, key&7
+		if fieldNumber == 0 || fieldNumber > 1<<29-1 {
+			return false
+		}
+		var size uint64
+		switch wireType {
+		case 0:
+			_, n := binary.Uvarint(buf)
+			if n == 0 {
+				return fields > 0
+			}
+			if n < 0 {
+				return false
+			}
+			size = uint64(n)
+		case 1:
+			size = 8
+		case 2:
+			length, n := binary.Uvarint(buf)
+			if n == 0 {
+				return fields > 0
+			}
+			if n < 0 {
+				return false
+			}
+			buf = buf[n:]
+			size = length
+		case 5:
+			size = 4
+		default:
+			// Groups are deprecated and the other wire types are invalid.
+			return false
+		}
+		if size > uint64(len(buf)) {
+			return fields > 0
+		}
+		buf = buf[size:]
+		fields++
+	}
+	return fields > 0
+}
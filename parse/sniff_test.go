@@ -0,0 +1,116 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"io"
+	"testing"
+)
+
+func TestSniff(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", ""},
+		{"json object", ` {"a": 1}`, MediaTypeJSON},
+		{"json array", "\xef\xbb\xbf[1, 2]", MediaTypeJSON},
+		{"xml", `<?xml version="1.0"?><a/>`, MediaTypeXML},
+		{"yaml document", "---\na: 1\n", MediaTypeYAML},
+		{"yaml directive", "%YAML 1.2\n---\n", MediaTypeYAML},
+		{"yaml mapping", "# comment\nname: katydid\n", MediaTypeYAML},
+		{"yaml nested mapping", "spec:\n  replicas: 3\n", MediaTypeYAML},
+		{"yaml sequence", "- a\n- b\n", MediaTypeYAML},
+		{"plain text", "hello world", ""},
+		{"text cut in rune", "a: \xc3", MediaTypeYAML},
+		{"cbor self describe", "\xd9\xd9\xf7\xa1\x61a\x01", MediaTypeCBOR},
+		{"cbor map", "\xa1\x61a\x01", MediaTypeCBOR},
+		{"cbor indefinite map", "\xbf\x61a\x01\xff", MediaTypeCBOR},
+		{"msgpack fixmap", "\x81\xa1a\x01", MediaTypeMessagePack},
+		{"msgpack map 16", "\xde\x00\x01\xa1a\x01", MediaTypeMessagePack},
+		{"protobuf", "\x08\x96\x01\x12\x03abc", MediaTypeProtobuf},
+		{"protobuf prefix", "\x08\x01\x0a\x05ab", MediaTypeProtobuf},
+		{"protobuf fixed", "\x0d\x01\x02\x03\x04\x11\x01\x02\x03\x04\x05\x06\x07\x08", MediaTypeProtobuf},
+		{"protobuf field zero", "\x00\x01", ""},
+		{"protobuf group", "\x0b\x0c", ""},
+		{"binary", "\x00\xff\xfe", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Sniff([]byte(test.input))
+			if got != test.want {
+				t.Fatalf("want %q, but got %q", test.want, got)
+			}
+		})
+	}
+}
+
+type emptyParser struct{}
+
+func (emptyParser) Init([]byte) {}
+
+func (emptyParser) Next() (Hint, error) {
+	return UnknownHint, io.EOF
+}
+
+func (emptyParser) Skip() error {
+	return io.EOF
+}
+
+func (emptyParser) Token() (Kind, []byte, error) {
+	return UnknownKind, nil, nil
+}
+
+func TestRegistry(t *testing.T) {
+	t.Cleanup(func() { unregister("application/x-test-empty") })
+	Register("application/x-test-empty", func() ParserWithInit { return emptyParser{} })
+	for _, mediaType := range []string{
+		"application/x-test-empty",
+		"Application/X-Test-Empty; charset=utf-8",
+		"application/vnd.example+x-test-empty",
+	} {
+		if New(mediaType) == nil {
+			t.Fatalf("want parser for %q", mediaType)
+		}
+	}
+	if New("application/x-test-unknown") != nil {
+		t.Fatalf("want no parser for unregistered media type")
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("want panic on duplicate Register")
+		}
+	}()
+	Register("application/x-test-empty", func() ParserWithInit { return emptyParser{} })
+}
+
+func TestRegistryFactoryRegisters(t *testing.T) {
+	// A factory that registers another parser would deadlock, if New held the lock while calling it.
+	t.Cleanup(func() {
+		unregister("application/x-test-lazy")
+		unregister("application/x-test-registered-by-factory")
+	})
+	Register("application/x-test-lazy", func() ParserWithInit {
+		Register("application/x-test-registered-by-factory", func() ParserWithInit { return emptyParser{} })
+		return emptyParser{}
+	})
+	if New("application/x-test-lazy") == nil {
+		t.Fatal("want a parser")
+	}
+	if New("application/x-test-registered-by-factory") == nil {
+		t.Fatal("want the parser that was registered by the factory")
+	}
+}
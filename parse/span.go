@@ -0,0 +1,66 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+// OffsetAble is an extra method for a Parser that reports where in the input the current token starts.
+// This is useful for editor integrations and error highlighting.
+type OffsetAble interface {
+	// Offset returns the byte offset in the input where the current token starts, or -1 if it is unknown.
+	Offset() int64
+}
+
+// RestartAble is an extra method for a ParserWithInit that reports where in the input it can be restarted.
+// This is not always the Offset, for example the offset of a value in a binary format could be the offset of its bytes,
+// after the header that describes them.
+type RestartAble interface {
+	// RestartOffset returns the byte offset in the input, where Init can be called to continue parsing,
+	// so that the next call to Next returns the current token again, or -1 if it is unknown.
+	RestartOffset() int64
+}
+
+// SpanAble is an extra method for a Parser that reports where in the input the current token starts and ends.
+// For an EnterHint or a LeaveHint the span is that of the opening or closing delimiter.
+type SpanAble interface {
+	// Span returns the byte offsets in the input where the current token starts and ends, or -1, -1 if it is unknown.
+	// The end offset is exclusive.
+	Span() (start, end int64)
+}
+
+// GetOffset returns the offset of the current token, if the parser implements OffsetAble or SpanAble.
+func GetOffset(p Parser) (int64, bool) {
+	if o, ok := p.(OffsetAble); ok {
+		offset := o.Offset()
+		return offset, offset >= 0
+	}
+	if s, ok := p.(SpanAble); ok {
+		start, _ := s.Span()
+		return start, start >= 0
+	}
+	return -1, false
+}
+
+// GetSpan returns the span of the current token, if the parser implements SpanAble.
+// If the parser only implements OffsetAble, then the returned start and end are both the offset.
+func GetSpan(p Parser) (start, end int64, ok bool) {
+	if s, ok := p.(SpanAble); ok {
+		start, end := s.Span()
+		return start, end, start >= 0 && end >= start
+	}
+	if o, ok := p.(OffsetAble); ok {
+		offset := o.Offset()
+		return offset, offset, offset >= 0
+	}
+	return -1, -1, false
+}
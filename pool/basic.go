@@ -14,39 +14,135 @@
 
 package pool
 
+import "math/bits"
+
+// class is a size class of buffers, which all have the same power of two size.
+type class struct {
+	bufs [][]byte
+	// used is the number of buffers that are busy, the rest are free.
+	used int
+}
+
 type pool struct {
-	free [][]byte
-	busy [][]byte
+	// minShift is the log2 of the smallest size class.
+	minShift int
+	// maxRetained is the maximum number of bytes that are kept after FreeAll, or zero for no maximum.
+	maxRetained int
+	size        int
+	classes     []class
+	stats       Stats
+	poison      bool
+	// gen is the number of calls to FreeAll, which is used to ignore marks that were made before the last FreeAll.
+	gen int
 }
 
-func New() Pool {
-	return &pool{
-		free: make([][]byte, 0),
-		busy: make([][]byte, 0),
+// New returns a pool that allocates buffers in power of two size classes.
+// Alloc and FreeAll do not search through the buffers, since each size class keeps a count of its busy buffers.
+func New(opts ...Option) Pool {
+	p := &pool{
+		minShift:    defaultMinShift,
+		maxRetained: defaultMaxRetained,
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	return p
 }
 
+// FreeAll frees all buffers and then releases the largest buffers until at most maxRetained bytes are retained.
 func (p *pool) FreeAll() {
-	p.free = append(p.free, p.busy...)
-	p.busy = p.busy[:0]
+	for i := range p.classes {
+		c := &p.classes[i]
+		if p.poison {
+			for _, buf := range c.bufs[:c.used] {
+				fill(buf)
+			}
+		}
+		c.used = 0
+	}
+	p.stats.InUse = 0
+	p.gen++
+	if p.maxRetained == 0 {
+		return
+	}
+	for i := len(p.classes) - 1; i >= 0 && p.stats.Allocated > p.maxRetained; i-- {
+		c := &p.classes[i]
+		for len(c.bufs) > 0 && p.stats.Allocated > p.maxRetained {
+			last := len(c.bufs) - 1
+			p.stats.Allocated -= cap(c.bufs[last])
+			c.bufs[last] = nil
+			c.bufs = c.bufs[:last]
+			p.size--
+		}
+	}
 }
 
 func (p *pool) Alloc(size int) []byte {
-	for i := 0; i < len(p.free); i++ {
-		if cap(p.free[i]) >= size {
-			buf := p.free[i]
-			p.free[i] = p.free[len(p.free)-1]
-			p.free = p.free[:len(p.free)-1]
-			p.busy = append(p.busy, buf)
-			return buf[:size]
-		}
+	i := 0
+	if size > 1<<p.minShift {
+		i = bits.Len(uint(size-1)) - p.minShift
 	}
-	// always allocate a big buffer, so hits when searching are very likely
-	buf := make([]byte, max(size*2, 1000))
-	p.busy = append(p.busy, buf)
+	if i >= len(p.classes) {
+		p.classes = append(p.classes, make([]class, i+1-len(p.classes))...)
+	}
+	c := &p.classes[i]
+	if c.used < len(c.bufs) {
+		buf := c.bufs[c.used]
+		c.used++
+		p.stats.use(len(buf), true)
+		return buf[:size]
+	}
+	buf := make([]byte, 1<<(i+p.minShift))
+	c.bufs = append(c.bufs, buf)
+	c.used++
+	p.stats.use(len(buf), false)
+	p.size++
 	return buf[:size]
 }
 
 func (p *pool) Size() int {
-	return len(p.free) + len(p.busy)
+	return p.size
+}
+
+// Mark records the number of busy buffers of each size class.
+func (p *pool) Mark() Mark {
+	used := make([]int, len(p.classes))
+	for i := range p.classes {
+		used[i] = p.classes[i].used
+	}
+	return Mark{gen: p.gen, used: used}
+}
+
+// ReleaseTo frees the buffers that were allocated after the mark.
+// Since the buffers of each size class are used in order, only the count of busy buffers per size class needs to be decreased.
+func (p *pool) ReleaseTo(m Mark) {
+	if m.gen != p.gen {
+		return
+	}
+	for i := range p.classes {
+		c := &p.classes[i]
+		used := 0
+		if i < len(m.used) {
+			used = m.used[i]
+		}
+		for c.used > used {
+			c.used--
+			if p.poison {
+				fill(c.bufs[c.used])
+			}
+			p.stats.InUse -= 1 << (i + p.minShift)
+		}
+	}
+}
+
+func (p *pool) Stats() Stats {
+	return p.stats
+}
+
+// fill fills the buffer with the Poison byte.
+func fill(buf []byte) {
+	buf = buf[:cap(buf)]
+	for i := range buf {
+		buf[i] = Poison
+	}
 }
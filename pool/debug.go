@@ -0,0 +1,130 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pool
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Debug is a Pool that records where each busy buffer was allocated,
+// so that buffers that are still busy when a document finishes can be reported.
+type Debug struct {
+	p    Pool
+	busy []Allocation
+	// gen is the number of calls to FreeAll, which is used to ignore marks that were made before the last FreeAll.
+	gen int
+}
+
+// Allocation is a buffer that was allocated from a Debug pool.
+type Allocation struct {
+	Size int
+	// Callers are the program counters of the function calls that allocated the buffer, see runtime.Callers.
+	Callers []uintptr
+}
+
+// String returns the size and the call sites of the allocation.
+func (a Allocation) String() string {
+	frames := runtime.CallersFrames(a.Callers)
+	sites := make([]string, 0, len(a.Callers))
+	for {
+		frame, more := frames.Next()
+		sites = append(sites, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+	return fmt.Sprintf("%d bytes allocated by %s", a.Size, strings.Join(sites, " <- "))
+}
+
+// debugCallers is the number of call sites that are recorded for each allocation.
+const debugCallers = 8
+
+// NewDebug returns a pool that allocates from p and records the call sites of the allocations.
+// This is slow and is meant for finding leaks in tests.
+func NewDebug(p Pool) *Debug {
+	return &Debug{p: p}
+}
+
+func (d *Debug) Alloc(size int) []byte {
+	pcs := make([]uintptr, debugCallers)
+	n := runtime.Callers(2, pcs)
+	d.busy = append(d.busy, Allocation{Size: size, Callers: pcs[:n]})
+	return d.p.Alloc(size)
+}
+
+func (d *Debug) FreeAll() {
+	d.busy = d.busy[:0]
+	d.gen++
+	d.p.FreeAll()
+}
+
+// Mark also marks the wrapped pool, if it implements MarkAble.
+func (d *Debug) Mark() Mark {
+	m := Mark{n: len(d.busy), gen: d.gen}
+	if p, ok := d.p.(MarkAble); ok {
+		inner := p.Mark()
+		m.inner = &inner
+	}
+	return m
+}
+
+// ReleaseTo also releases the wrapped pool, if it implements MarkAble,
+// otherwise the released buffers are no longer reported as busy, but they are only freed by FreeAll.
+func (d *Debug) ReleaseTo(m Mark) {
+	if m.gen != d.gen || m.n > len(d.busy) {
+		return
+	}
+	d.busy = d.busy[:m.n]
+	if m.inner != nil {
+		d.p.(MarkAble).ReleaseTo(*m.inner)
+	}
+}
+
+func (d *Debug) Size() int {
+	return d.p.Size()
+}
+
+// Stats returns the statistics of the wrapped pool, if it implements StatsAble,
+// otherwise only InUse is reported.
+func (d *Debug) Stats() Stats {
+	if s, ok := GetStats(d.p); ok {
+		return s
+	}
+	inUse := 0
+	for _, a := range d.busy {
+		inUse += a.Size
+	}
+	return Stats{InUse: inUse}
+}
+
+// Busy returns the allocations since the last FreeAll.
+func (d *Debug) Busy() []Allocation {
+	return d.busy
+}
+
+// Check returns an error that lists the buffers that are still busy,
+// which is useful to call when a document is finished and all buffers should have been freed.
+func (d *Debug) Check() error {
+	if len(d.busy) == 0 {
+		return nil
+	}
+	sites := make([]string, len(d.busy))
+	for i, a := range d.busy {
+		sites[i] = a.String()
+	}
+	return fmt.Errorf("%d buffers are still busy:\n%s", len(d.busy), strings.Join(sites, "\n"))
+}
@@ -29,3 +29,9 @@ func (p *none) Alloc(size int) []byte {
 func (p *none) Size() int {
 	return 0
 }
+
+func (p *none) Mark() Mark {
+	return Mark{}
+}
+
+func (p *none) ReleaseTo(Mark) {}
@@ -0,0 +1,55 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pool
+
+import "math/bits"
+
+// Option is used set options when creating a new Pool.
+type Option func(*pool)
+
+const defaultMinShift = 8
+
+const defaultMaxRetained = 64 << 20
+
+// WithMinChunk sets the size of the smallest buffers that are allocated, which is rounded up to a power of two.
+// The default is 256 bytes.
+func WithMinChunk(size int) func(*pool) {
+	return func(p *pool) {
+		p.minShift = 0
+		if size > 1 {
+			p.minShift = bits.Len(uint(size - 1))
+		}
+	}
+}
+
+// WithMaxRetained sets the maximum number of bytes of buffers that are kept for reuse after FreeAll,
+// so that one huge document does not keep its memory forever.
+// Zero means that all buffers are kept. The default is 64 MiB.
+func WithMaxRetained(size int) func(*pool) {
+	return func(p *pool) {
+		p.maxRetained = size
+	}
+}
+
+// Poison is the byte that freed buffers are filled with, when WithPoison is used.
+const Poison = 0xDB
+
+// WithPoison fills buffers with the Poison byte when they are freed by FreeAll or ReleaseTo.
+// This is meant for tests, to catch consumers that hold on to token bytes past their lifetime.
+func WithPoison() func(*pool) {
+	return func(p *pool) {
+		p.poison = true
+	}
+}
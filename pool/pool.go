@@ -19,3 +19,25 @@ type Pool interface {
 	Alloc(size int) []byte
 	Size() int
 }
+
+// MarkAble is an extra method for a Pool that can free the buffers that were allocated after a mark.
+// This allows a consumer to free the buffers of one subtree, for example one array element in a huge list.
+type MarkAble interface {
+	// Mark marks the current allocations, so that the allocations after the mark can be released with ReleaseTo.
+	Mark() Mark
+	// ReleaseTo frees the buffers that were allocated after the mark, without freeing the buffers that were allocated before it.
+	// Marks are nested, so releasing to a mark also releases all later marks.
+	// FreeAll invalidates all marks, so releasing to a mark that was made before FreeAll does nothing.
+	ReleaseTo(m Mark)
+}
+
+// Mark is returned by MarkAble.Mark and is passed to MarkAble.ReleaseTo.
+type Mark struct {
+	n int
+	// gen is the number of calls to FreeAll before the mark was made.
+	gen int
+	// used is the number of busy buffers of each size class, for pools that keep the buffers of a size class in order.
+	used []int
+	// inner is the mark of a wrapped pool.
+	inner *Mark
+}
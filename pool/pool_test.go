@@ -0,0 +1,272 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pool
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAlloc(t *testing.T) {
+	p := New(WithMinChunk(10))
+	for _, size := range []int{0, 1, 16, 17, 1000} {
+		buf := p.Alloc(size)
+		if len(buf) != size {
+			t.Fatalf("want length %d, but got %d", size, len(buf))
+		}
+		if c := cap(buf); c < 16 || c&(c-1) != 0 || c < size {
+			t.Fatalf("want a power of two capacity of at least 16, but got %d for size %d", c, size)
+		}
+	}
+	if p.Size() != 5 {
+		t.Fatalf("want 5 buffers, but got %d", p.Size())
+	}
+	a := p.Alloc(100)
+	p.FreeAll()
+	b := p.Alloc(100)
+	if &a[0] != &b[0] {
+		t.Fatal("want the buffer to be reused after FreeAll")
+	}
+	c := p.Alloc(100)
+	if &b[0] == &c[0] {
+		t.Fatal("want a busy buffer to not be reused")
+	}
+}
+
+func TestMaxRetained(t *testing.T) {
+	p := New(WithMinChunk(16), WithMaxRetained(1024))
+	for i := 0; i < 10; i++ {
+		p.Alloc(1000)
+	}
+	small := p.Alloc(10)
+	p.FreeAll()
+	if p.Size() != 1 {
+		t.Fatalf("want only the small buffer to be retained, but got %d buffers", p.Size())
+	}
+	if again := p.Alloc(10); &again[0] != &small[0] {
+		t.Fatal("want the small buffer to be reused")
+	}
+}
+
+// sizes returns the sizes of the allocations of a document with many small tokens and a few large ones.
+func sizes() []int {
+	r := rand.New(rand.NewSource(0))
+	sizes := make([]int, 5000)
+	for i := range sizes {
+		if r.Intn(100) == 0 {
+			sizes[i] = 1000 + r.Intn(10000)
+		} else {
+			sizes[i] = 1 + r.Intn(64)
+		}
+	}
+	return sizes
+}
+
+func benchmarkPool(b *testing.B, p Pool) {
+	sizes := sizes()
+	for b.Loop() {
+		for _, size := range sizes {
+			p.Alloc(size)
+		}
+		p.FreeAll()
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	benchmarkPool(b, New())
+}
+
+func BenchmarkLinear(b *testing.B) {
+	benchmarkPool(b, newLinear())
+}
+
+func BenchmarkNone(b *testing.B) {
+	benchmarkPool(b, None())
+}
+
+func TestShared(t *testing.T) {
+	shared := NewShared(WithMinChunk(16))
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			l := shared.Lease()
+			for doc := 0; doc < 100; doc++ {
+				bufs := make([][]byte, 0, 50)
+				for i := 0; i < 50; i++ {
+					buf := l.Alloc(1 + (i*w)%100)
+					for j := range buf {
+						buf[j] = byte(w)
+					}
+					bufs = append(bufs, buf)
+				}
+				// Other workers freeing their leases should not touch our buffers.
+				for _, buf := range bufs {
+					for j := range buf {
+						if buf[j] != byte(w) {
+							t.Errorf("worker %d: buffer was modified by another worker", w)
+							return
+						}
+					}
+				}
+				if l.Size() != 50 {
+					t.Errorf("want 50 busy buffers, but got %d", l.Size())
+				}
+				l.FreeAll()
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+func BenchmarkShared(b *testing.B) {
+	benchmarkPool(b, NewShared().Lease())
+}
+
+func TestStats(t *testing.T) {
+	p := New(WithMinChunk(16))
+	p.Alloc(10)
+	p.Alloc(20)
+	p.FreeAll()
+	p.Alloc(5)
+	stats, ok := GetStats(p)
+	if !ok {
+		t.Fatal("want stats")
+	}
+	want := Stats{Allocated: 48, InUse: 16, HighWater: 48, Hits: 1, Misses: 2}
+	if stats != want {
+		t.Fatalf("want %+v, but got %+v", want, stats)
+	}
+}
+
+func TestDebug(t *testing.T) {
+	d := NewDebug(New())
+	d.Alloc(10)
+	err := d.Check()
+	if err == nil {
+		t.Fatal("want an error for a busy buffer")
+	}
+	if !strings.Contains(err.Error(), "TestDebug") {
+		t.Fatalf("want the call site in the error, but got %v", err)
+	}
+	d.FreeAll()
+	if err := d.Check(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// markPool is a Pool that implements MarkAble.
+type markPool interface {
+	Pool
+	MarkAble
+}
+
+func TestReleaseTo(t *testing.T) {
+	pools := map[string]markPool{
+		"New":    New().(markPool),
+		"Shared": NewShared().Lease().(markPool),
+		"Debug":  NewDebug(New()),
+		"None":   None().(markPool),
+		"Linear": newLinear().(markPool),
+	}
+	for name, p := range pools {
+		t.Run(name, func(t *testing.T) {
+			before := p.Alloc(10)
+			copy(before, "0123456789")
+			outer := p.Mark()
+			p.Alloc(100)
+			inner := p.Mark()
+			p.Alloc(1000)
+			p.ReleaseTo(inner)
+			p.ReleaseTo(outer)
+			for i := 0; i < 10; i++ {
+				buf := p.Alloc(10)
+				copy(buf, "abcdefghij")
+				p.ReleaseTo(outer)
+			}
+			if string(before) != "0123456789" {
+				t.Fatalf("want the buffer allocated before the mark to be untouched, but got %q", before)
+			}
+			if stats, ok := GetStats(p); ok && stats.InUse != cap(before) {
+				t.Fatalf("want only the buffer before the mark in use, but got %+v", stats)
+			}
+			if d, ok := p.(*Debug); ok && len(d.Busy()) != 1 {
+				t.Fatalf("want one busy buffer, but got %d", len(d.Busy()))
+			}
+		})
+	}
+}
+
+func TestReleaseToReuse(t *testing.T) {
+	p := New().(markPool)
+	m := p.Mark()
+	a := p.Alloc(10)
+	p.ReleaseTo(m)
+	if b := p.Alloc(10); &a[0] != &b[0] {
+		t.Fatal("want the released buffer to be reused")
+	}
+}
+
+func TestReleaseToStale(t *testing.T) {
+	pools := map[string]markPool{
+		"New":    New().(markPool),
+		"Shared": NewShared().Lease().(markPool),
+		"Debug":  NewDebug(New()),
+		"Linear": newLinear().(markPool),
+	}
+	for name, p := range pools {
+		t.Run(name, func(t *testing.T) {
+			p.Alloc(10)
+			stale := p.Mark()
+			p.FreeAll()
+			buf := p.Alloc(10)
+			copy(buf, "0123456789")
+			p.Alloc(10)
+			p.ReleaseTo(stale)
+			if stats, ok := GetStats(p); ok && stats.InUse != 2*cap(buf) {
+				t.Fatalf("want a mark made before FreeAll to release nothing, but got %+v", stats)
+			}
+			if d, ok := p.(*Debug); ok && len(d.Busy()) != 2 {
+				t.Fatalf("want two busy buffers, but got %d", len(d.Busy()))
+			}
+			if other := p.Alloc(10); &other[0] == &buf[0] {
+				t.Fatal("want the buffer allocated after FreeAll to stay busy")
+			}
+		})
+	}
+}
+
+func TestPoison(t *testing.T) {
+	p := New(WithPoison()).(markPool)
+	a := p.Alloc(4)
+	copy(a, "abcd")
+	m := p.Mark()
+	b := p.Alloc(4)
+	copy(b, "efgh")
+	p.ReleaseTo(m)
+	if string(a) != "abcd" {
+		t.Fatalf("want the buffer before the mark to be untouched, but got %q", a)
+	}
+	if b[0] != Poison {
+		t.Fatalf("want the released buffer to be poisoned, but got %q", b)
+	}
+	p.FreeAll()
+	if a[0] != Poison {
+		t.Fatalf("want the freed buffer to be poisoned, but got %q", a)
+	}
+}
@@ -0,0 +1,107 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pool
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Shared is a goroutine-safe source of buffers, which are shared between leases.
+// Each goroutine should take its own lease, since a lease is not goroutine-safe.
+type Shared struct {
+	minShift int
+	classes  [64]sync.Pool
+}
+
+// NewShared returns a goroutine-safe source of buffers in power of two size classes, backed by a sync.Pool per size class.
+// Only the WithMinChunk option is used, since the garbage collector releases the buffers that are not in use.
+func NewShared(opts ...Option) *Shared {
+	p := &pool{minShift: defaultMinShift}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return &Shared{minShift: p.minShift}
+}
+
+// Lease returns a Pool that allocates buffers from the shared pool.
+// FreeAll only frees the buffers that were allocated by the lease,
+// so one goroutine finishing a document cannot free buffers that are in use by another.
+func (s *Shared) Lease() Pool {
+	return &lease{
+		shared: s,
+		busy:   make([]*[]byte, 0),
+	}
+}
+
+type lease struct {
+	shared *Shared
+	busy   []*[]byte
+	// stats are the statistics of this lease, where Allocated is the number of bytes that this lease created.
+	stats Stats
+	// gen is the number of calls to FreeAll, which is used to ignore marks that were made before the last FreeAll.
+	gen int
+}
+
+func (l *lease) Alloc(size int) []byte {
+	s := l.shared
+	i := 0
+	if size > 1<<s.minShift {
+		i = bits.Len(uint(size-1)) - s.minShift
+	}
+	buf, ok := s.classes[i].Get().(*[]byte)
+	if !ok {
+		b := make([]byte, 1<<(i+s.minShift))
+		buf = &b
+	}
+	l.stats.use(cap(*buf), ok)
+	l.busy = append(l.busy, buf)
+	return (*buf)[:size]
+}
+
+func (l *lease) FreeAll() {
+	l.release(0)
+	l.gen++
+}
+
+// release puts the buffers that were allocated after the first n back into the shared pool.
+func (l *lease) release(n int) {
+	s := l.shared
+	for j, buf := range l.busy[n:] {
+		i := bits.Len(uint(cap(*buf)-1)) - s.minShift
+		l.stats.InUse -= cap(*buf)
+		s.classes[i].Put(buf)
+		l.busy[n+j] = nil
+	}
+	l.busy = l.busy[:n]
+}
+
+func (l *lease) Mark() Mark {
+	return Mark{n: len(l.busy), gen: l.gen}
+}
+
+func (l *lease) ReleaseTo(m Mark) {
+	if m.gen == l.gen && m.n <= len(l.busy) {
+		l.release(m.n)
+	}
+}
+
+func (l *lease) Size() int {
+	return len(l.busy)
+}
+
+func (l *lease) Stats() Stats {
+	return l.stats
+}
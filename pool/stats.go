@@ -0,0 +1,54 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pool
+
+// Stats are the usage statistics of a Pool.
+type Stats struct {
+	// Allocated is the number of bytes of buffers that are owned by the pool, whether they are busy or free.
+	Allocated int
+	// InUse is the number of bytes of buffers that are busy, which means they were allocated since the last FreeAll.
+	InUse int
+	// HighWater is the maximum that InUse has been.
+	HighWater int
+	// Hits is the number of allocations that reused a free buffer.
+	Hits int
+	// Misses is the number of allocations that created a new buffer.
+	Misses int
+}
+
+// StatsAble is an extra method for a Pool that reports usage statistics.
+type StatsAble interface {
+	Stats() Stats
+}
+
+// GetStats returns the usage statistics of the pool, if it implements StatsAble.
+func GetStats(p Pool) (Stats, bool) {
+	if s, ok := p.(StatsAble); ok {
+		return s.Stats(), true
+	}
+	return Stats{}, false
+}
+
+// use records that a buffer of the given capacity became busy.
+func (s *Stats) use(size int, hit bool) {
+	s.InUse += size
+	s.HighWater = max(s.HighWater, s.InUse)
+	if hit {
+		s.Hits++
+	} else {
+		s.Misses++
+		s.Allocated += size
+	}
+}
@@ -0,0 +1,135 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package replay records the tokens produced by a parse.Parser into a compact binary token stream,
+// so that it can be replayed later without the original format library.
+//
+// The token stream is a list of events, where each event is encoded as:
+//
+//	hint byte, kind byte, uvarint length, length bytes
+//
+// For a FieldHint or a ValueHint the kind is the parse.Kind and the bytes are the token bytes.
+// For an EnterHint the kind is the jsonschema.JSONSchemaType, or zero if unknown,
+// and the bytes are the uvarint number of fields of an object or elements of an array,
+// followed by the events of the subtree, up to and including the matching LeaveHint.
+// This allows Skip to jump over a whole subtree and Len to return the length without decoding the subtree.
+// For a LeaveHint the kind and length are zero.
+//
+// Token bytes are stored as they are returned by the parser,
+// so Int64Kind, Uint64Kind and Float64Kind tokens are stored in the byte order used by the cast package.
+package replay
+
+import (
+	"encoding/binary"
+
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// Encoder encodes events into a token stream.
+// The zero value is ready to use.
+type Encoder struct {
+	buf []byte
+	// open is a stack of the open subtrees.
+	open []subtree
+}
+
+type subtree struct {
+	// start is the offset where the events of the subtree start.
+	start int
+	// count is the number of fields of an object or elements of an array.
+	count  int
+	object bool
+}
+
+// reserved is the number of bytes reserved for the length and for the count of an open subtree.
+const reserved = binary.MaxVarintLen32
+
+var zeros [2 * reserved]byte
+
+// Enter starts a subtree, that has to be closed by Leave.
+func (e *Encoder) Enter(typ jsonschema.JSONSchemaType) {
+	e.countElement()
+	e.buf = append(e.buf, byte(parse.EnterHint), byte(typ))
+	e.buf = append(e.buf, zeros[:]...)
+	e.open = append(e.open, subtree{start: len(e.buf), object: typ == jsonschema.JSONSchemaTypeObject})
+}
+
+// Field encodes a field name.
+func (e *Encoder) Field(kind parse.Kind, value []byte) {
+	if len(e.open) > 0 && e.open[len(e.open)-1].object {
+		e.open[len(e.open)-1].count++
+	}
+	e.token(parse.FieldHint, kind, value)
+}
+
+// Value encodes a value.
+func (e *Encoder) Value(kind parse.Kind, value []byte) {
+	e.countElement()
+	e.token(parse.ValueHint, kind, value)
+}
+
+// countElement counts a value or subtree as an element, if the open subtree is not an object.
+func (e *Encoder) countElement() {
+	if len(e.open) > 0 && !e.open[len(e.open)-1].object {
+		e.open[len(e.open)-1].count++
+	}
+}
+
+func (e *Encoder) token(hint parse.Hint, kind parse.Kind, value []byte) {
+	e.buf = append(e.buf, byte(hint), byte(kind))
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(value)))
+	e.buf = append(e.buf, value...)
+}
+
+// Leave closes the subtree started by the last unclosed Enter.
+func (e *Encoder) Leave() error {
+	if len(e.open) == 0 {
+		return errUnexpectedLeave
+	}
+	top := e.open[len(e.open)-1]
+	e.open = e.open[:len(e.open)-1]
+	e.buf = append(e.buf, byte(parse.LeaveHint), 0, 0)
+	var count [reserved]byte
+	c := binary.PutUvarint(count[:], uint64(top.count))
+	length := c + len(e.buf) - top.start
+	if uint64(length) >= 1<<(7*reserved) {
+		return errTooLarge
+	}
+	// Write the length and the count into the reserved bytes and
+	// move the subtree up to keep the encoding of the length and the count minimal.
+	var header [2 * reserved]byte
+	n := binary.PutUvarint(header[:], uint64(length))
+	n += copy(header[n:], count[:c])
+	headerStart := top.start - len(header)
+	copy(e.buf[headerStart+n:], e.buf[top.start:])
+	copy(e.buf[headerStart:], header[:n])
+	e.buf = e.buf[:len(e.buf)-(len(header)-n)]
+	return nil
+}
+
+// Bytes returns the encoded token stream.
+// It returns an error if an Enter has not been closed by a Leave.
+func (e *Encoder) Bytes() ([]byte, error) {
+	if len(e.open) != 0 {
+		return nil, errUnclosedEnter
+	}
+	return e.buf, nil
+}
+
+// Reset resets the encoder, but keeps the allocated memory, so that it can be reused.
+func (e *Encoder) Reset() {
+	e.buf = e.buf[:0]
+	e.open = e.open[:0]
+}
@@ -0,0 +1,27 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package replay
+
+import "errors"
+
+var errUnexpectedLeave = errors.New("unexpected leave, without matching enter")
+
+var errUnclosedEnter = errors.New("enter is not closed by a matching leave")
+
+var errCorrupt = errors.New("corrupt token stream")
+
+var errTooLarge = errors.New("subtree is too large to encode")
+
+var errNotEnter = errors.New("raw subtree is only available after an enter")
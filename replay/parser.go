@@ -0,0 +1,275 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package replay
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+type Parser interface {
+	parse.ParserWithInit
+	jsonschema.JSONSchemaAble
+	Reset()
+}
+
+type parser struct {
+	buf []byte
+	// offset is the offset of the next event.
+	offset int
+	// ends is a stack of the offsets where the open subtrees end.
+	ends []int
+	// the current event
+	hint  parse.Hint
+	kind  byte
+	value []byte
+	// length is the number of fields or elements of the subtree that was just entered.
+	length int
+	// eventStart is the offset where the current event starts.
+	eventStart int
+	start      int
+	end        int
+}
+
+// NewParser returns a parser that replays a token stream, which is passed to Init.
+// Token returns slices of the token stream, so it never allocates.
+func NewParser() Parser {
+	return &parser{
+		ends: make([]int, 0, 10),
+	}
+}
+
+func (p *parser) Init(buf []byte) {
+	p.buf = buf
+	p.Reset()
+}
+
+func (p *parser) Reset() {
+	p.offset = 0
+	// Shrink the stack's length, but keep it's capacity,
+	// so we can reuse it on the next parse.
+	p.ends = p.ends[:0]
+	p.hint = parse.UnknownHint
+	p.kind = 0
+	p.value = nil
+	p.eventStart = -1
+	p.start = -1
+	p.end = -1
+}
+
+func (p *parser) JSONSchemaType() jsonschema.JSONSchemaType {
+	if p.hint != parse.EnterHint {
+		return jsonschema.JSONSchemaTypeUnknown
+	}
+	return jsonschema.JSONSchemaType(p.kind)
+}
+
+// event decodes the header of the event at offset
+// and returns the offset where the event's bytes start and end.
+func (p *parser) event(offset int) (start int, end int, err error) {
+	if offset+2 > len(p.buf) {
+		return 0, 0, errCorrupt
+	}
+	length, n := binary.Uvarint(p.buf[offset+2:])
+	if n <= 0 {
+		return 0, 0, errCorrupt
+	}
+	start = offset + 2 + n
+	if length > uint64(len(p.buf)-start) {
+		return 0, 0, errCorrupt
+	}
+	return start, start + int(length), nil
+}
+
+func (p *parser) Next() (parse.Hint, error) {
+	if p.offset >= len(p.buf) {
+		if len(p.ends) > 0 {
+			return parse.UnknownHint, io.ErrUnexpectedEOF
+		}
+		return parse.UnknownHint, io.EOF
+	}
+	eventStart := p.offset
+	start, end, err := p.event(p.offset)
+	if err != nil {
+		return parse.UnknownHint, err
+	}
+	hint := parse.Hint(p.buf[p.offset])
+	p.kind = p.buf[p.offset+1]
+	switch hint {
+	case parse.EnterHint:
+		count, n := binary.Uvarint(p.buf[start:end])
+		if n <= 0 {
+			return parse.UnknownHint, errCorrupt
+		}
+		p.ends = append(p.ends, end)
+		p.value = nil
+		p.length = int(count)
+		// The span of an enter is its header, including the count.
+		p.start, p.end = p.offset, start+n
+		p.offset = start + n
+	case parse.FieldHint, parse.ValueHint:
+		p.value = p.buf[start:end]
+		p.start, p.end = start, end
+		p.offset = end
+	case parse.LeaveHint:
+		if len(p.ends) == 0 || p.ends[len(p.ends)-1] != end {
+			return parse.UnknownHint, errCorrupt
+		}
+		p.ends = p.ends[:len(p.ends)-1]
+		p.value = nil
+		p.start, p.end = p.offset, end
+		p.offset = end
+	default:
+		return parse.UnknownHint, errCorrupt
+	}
+	p.eventStart = eventStart
+	p.hint = hint
+	return hint, nil
+}
+
+func (p *parser) Skip() error {
+	switch p.hint {
+	case parse.EnterHint, parse.ValueHint:
+		// Skip the whole object or array that was entered, or the rest of the object or array that the value is in.
+		if len(p.ends) > 0 {
+			top := len(p.ends) - 1
+			p.offset = p.ends[top]
+			p.ends = p.ends[:top]
+		}
+	case parse.FieldHint:
+		// Skip the field's value, which is one event, including its subtree.
+		_, end, err := p.event(p.offset)
+		if err != nil {
+			return err
+		}
+		p.offset = end
+	default:
+		_, err := p.Next()
+		return err
+	}
+	p.hint = parse.UnknownHint
+	return nil
+}
+
+// NextN fills events with the next tokens, see parse.NextNAble.
+// The events' values are slices of the token stream, so they stay valid after the next call to NextN.
+func (p *parser) NextN(events []parse.Event) (int, error) {
+	for n := range events {
+		hint, err := p.Next()
+		if err != nil {
+			return n, err
+		}
+		events[n] = parse.Event{Hint: hint, Kind: parse.Kind(p.kind), Value: p.value}
+		if hint == parse.EnterHint || hint == parse.LeaveHint {
+			events[n].Kind = parse.UnknownKind
+		}
+	}
+	return len(events), nil
+}
+
+// SkipTo skips to the field with the given name, see parse.SkipToAble.
+// Fields and their values are skipped using their encoded lengths, without decoding them.
+func (p *parser) SkipTo(name []byte) (bool, error) {
+	for {
+		if p.offset >= len(p.buf) {
+			return false, io.ErrUnexpectedEOF
+		}
+		start, end, err := p.event(p.offset)
+		if err != nil {
+			return false, err
+		}
+		switch parse.Hint(p.buf[p.offset]) {
+		case parse.FieldHint:
+			kind := parse.Kind(p.buf[p.offset+1])
+			if (kind == parse.StringKind || kind == parse.BytesKind) && bytes.Equal(p.buf[start:end], name) {
+				_, err := p.Next()
+				return true, err
+			}
+			// Skip the field and its value.
+			_, end, err = p.event(end)
+			if err != nil {
+				return false, err
+			}
+			p.offset = end
+			p.hint = parse.UnknownHint
+		case parse.LeaveHint:
+			_, err := p.Next()
+			return false, err
+		default:
+			return false, parse.ErrNotObject
+		}
+	}
+}
+
+// Len returns the number of fields of the object or elements of the array that was just entered,
+// which is stored in the token stream, so Len does not need to look at the subtree.
+func (p *parser) Len() (int, bool) {
+	if p.hint != parse.EnterHint || len(p.ends) == 0 {
+		return 0, false
+	}
+	return p.length, true
+}
+
+// Offset returns the offset in the token stream where the current token starts, see Span.
+func (p *parser) Offset() int64 {
+	return int64(p.start)
+}
+
+// RestartOffset returns the offset in the token stream where the current event starts, see parse.RestartAble.
+func (p *parser) RestartOffset() int64 {
+	return int64(p.eventStart)
+}
+
+// Span returns the span of the current token in the token stream.
+// For a FieldHint or ValueHint it is the span of the token's bytes,
+// otherwise it is the span of the event.
+func (p *parser) Span() (int64, int64) {
+	return int64(p.start), int64(p.end)
+}
+
+// RawToken returns the whole encoded event of the current token,
+// which can be appended to another token stream.
+func (p *parser) RawToken() ([]byte, error) {
+	if p.hint == parse.UnknownHint {
+		return nil, nil
+	}
+	return p.buf[p.eventStart:p.end], nil
+}
+
+// RawSubtree returns the encoded subtree that was entered, including its enter and leave events,
+// and moves past the subtree, without decoding it.
+func (p *parser) RawSubtree() ([]byte, error) {
+	if p.hint != parse.EnterHint || len(p.ends) == 0 {
+		return nil, errNotEnter
+	}
+	top := len(p.ends) - 1
+	raw := p.buf[p.eventStart:p.ends[top]]
+	p.offset = p.ends[top]
+	p.ends = p.ends[:top]
+	p.hint = parse.UnknownHint
+	return raw, nil
+}
+
+func (p *parser) Token() (parse.Kind, []byte, error) {
+	switch p.hint {
+	case parse.FieldHint, parse.ValueHint:
+		return parse.Kind(p.kind), p.value, nil
+	}
+	return parse.UnknownKind, nil, nil
+}
@@ -0,0 +1,157 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package replay
+
+import (
+	"io"
+
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// Recorder is a parser that records the tokens of the parser that it wraps.
+type Recorder interface {
+	parse.Parser
+	jsonschema.JSONSchemaAble
+	// Bytes returns the recorded token stream.
+	Bytes() ([]byte, error)
+}
+
+type recorder struct {
+	p     parse.Parser
+	enc   Encoder
+	hint  parse.Hint
+	depth int
+}
+
+// NewRecorder returns a parser that records all the tokens of p, while passing them through.
+// Skip is implemented by calling Next on p, so that skipped tokens are also recorded.
+func NewRecorder(p parse.Parser) Recorder {
+	return &recorder{p: p}
+}
+
+// Record records all the tokens of p into a token stream.
+func Record(p parse.Parser) ([]byte, error) {
+	r := &recorder{p: p}
+	for {
+		if _, err := r.Next(); err != nil {
+			if err == io.EOF {
+				return r.Bytes()
+			}
+			return nil, err
+		}
+	}
+}
+
+// RecordSubtree records the subtree of p, after Next returned an EnterHint, up to and including its LeaveHint.
+// RecordSubtree can be used as the fallback encoder for parse.RawSubtree.
+func RecordSubtree(p parse.Parser) ([]byte, error) {
+	r := &recorder{p: p, depth: 1}
+	r.enc.Enter(r.JSONSchemaType())
+	if err := r.leave(0); err != nil {
+		return nil, err
+	}
+	return r.Bytes()
+}
+
+func (r *recorder) Bytes() ([]byte, error) {
+	return r.enc.Bytes()
+}
+
+func (r *recorder) JSONSchemaType() jsonschema.JSONSchemaType {
+	if s, ok := r.p.(jsonschema.JSONSchemaAble); ok {
+		return s.JSONSchemaType()
+	}
+	return jsonschema.JSONSchemaTypeUnknown
+}
+
+func (r *recorder) Next() (parse.Hint, error) {
+	h, err := r.p.Next()
+	if err != nil {
+		return parse.UnknownHint, err
+	}
+	switch h {
+	case parse.EnterHint:
+		r.enc.Enter(r.JSONSchemaType())
+		r.depth++
+	case parse.FieldHint, parse.ValueHint:
+		kind, value, err := r.p.Token()
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		if h == parse.FieldHint {
+			r.enc.Field(kind, value)
+		} else {
+			r.enc.Value(kind, value)
+		}
+	case parse.LeaveHint:
+		if err := r.enc.Leave(); err != nil {
+			return parse.UnknownHint, err
+		}
+		r.depth--
+	}
+	r.hint = h
+	return h, nil
+}
+
+func (r *recorder) Skip() error {
+	switch r.hint {
+	case parse.EnterHint:
+		// Skip the whole object or array.
+		if err := r.leave(r.depth - 1); err != nil {
+			return err
+		}
+	case parse.FieldHint:
+		// Skip the field's value.
+		h, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if h == parse.EnterHint {
+			if err := r.leave(r.depth - 1); err != nil {
+				return err
+			}
+		}
+	case parse.ValueHint:
+		// Skip the rest of the object or array.
+		if r.depth > 0 {
+			if err := r.leave(r.depth - 1); err != nil {
+				return err
+			}
+		}
+	default:
+		_, err := r.Next()
+		return err
+	}
+	r.hint = parse.UnknownHint
+	return nil
+}
+
+// leave calls Next until the depth is reduced to the given depth.
+func (r *recorder) leave(depth int) error {
+	for r.depth > depth {
+		if _, err := r.Next(); err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *recorder) Token() (parse.Kind, []byte, error) {
+	return r.p.Token()
+}
@@ -0,0 +1,397 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package replay
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/expect"
+	"katydid.org.za/go/parser-go/fstree"
+	"katydid.org.za/go/parser-go/hedge"
+	"katydid.org.za/go/parser-go/internal/fstreetest"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/parse/debug"
+)
+
+func alloc(size int) []byte {
+	return make([]byte, size)
+}
+
+func TestEncoder(t *testing.T) {
+	enc := &Encoder{}
+	enc.Enter(jsonschema.JSONSchemaTypeObject)
+	enc.Field(parse.StringKind, []byte("a"))
+	enc.Value(parse.Int64Kind, cast.FromInt64(1, alloc))
+	enc.Field(parse.StringKind, []byte("b"))
+	enc.Enter(jsonschema.JSONSchemaTypeArray)
+	enc.Value(parse.StringKind, []byte(strings.Repeat("x", 200)))
+	enc.Value(parse.TrueKind, nil)
+	expect.NoErr(t, enc.Leave)
+	expect.NoErr(t, enc.Leave)
+	buf, err := enc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser()
+	p.Init(buf)
+	expect.Hint(t, p, parse.EnterHint)
+	if typ := p.JSONSchemaType(); typ != jsonschema.JSONSchemaTypeObject {
+		t.Fatalf("want object, but got %c", typ)
+	}
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.EnterHint)
+	if typ := p.JSONSchemaType(); typ != jsonschema.JSONSchemaTypeArray {
+		t.Fatalf("want array, but got %c", typ)
+	}
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, strings.Repeat("x", 200))
+	expect.Hint(t, p, parse.ValueHint)
+	expect.True(t, p)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestEncoderErrors(t *testing.T) {
+	enc := &Encoder{}
+	if err := enc.Leave(); err == nil {
+		t.Fatal("expected error for leave without enter")
+	}
+	enc.Enter(jsonschema.JSONSchemaTypeUnknown)
+	if _, err := enc.Bytes(); err == nil {
+		t.Fatal("expected error for unclosed enter")
+	}
+}
+
+func TestSkip(t *testing.T) {
+	enc := &Encoder{}
+	enc.Enter(jsonschema.JSONSchemaTypeObject)
+	enc.Field(parse.StringKind, []byte("a"))
+	enc.Enter(jsonschema.JSONSchemaTypeArray)
+	enc.Value(parse.NullKind, nil)
+	expect.NoErr(t, enc.Leave)
+	enc.Field(parse.StringKind, []byte("b"))
+	enc.Value(parse.FalseKind, nil)
+	enc.Field(parse.StringKind, []byte("c"))
+	enc.Value(parse.TrueKind, nil)
+	expect.NoErr(t, enc.Leave)
+	buf, err := enc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser()
+	p.Init(buf)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.False(t, p)
+	expect.NoErr(t, p.Skip)
+	expect.EOF(t, p)
+
+	p.Reset()
+	expect.Hint(t, p, parse.EnterHint)
+	expect.NoErr(t, p.Skip)
+	expect.EOF(t, p)
+}
+
+func TestCorrupt(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte{byte(parse.ValueHint), byte(parse.StringKind), 10, 'a'})
+	if _, err := p.Next(); err == nil {
+		t.Fatal("expected error for length that is too long")
+	}
+	p.Init([]byte{byte(parse.LeaveHint), 0, 0})
+	if _, err := p.Next(); err == nil {
+		t.Fatal("expected error for leave without enter")
+	}
+}
+
+func record(t *testing.T) []byte {
+	t.Helper()
+	buf, err := Record(fstree.NewParser(fstreetest.NewFS(), fstree.WithContents()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+func TestRecordReplay(t *testing.T) {
+	want, err := hedge.ParseInto(fstree.NewParser(fstreetest.NewFS(), fstree.WithContents()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser()
+	p.Init(record(t))
+	got, err := hedge.ParseInto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+func TestRandomSkip(t *testing.T) {
+	buf := record(t)
+	p := NewParser()
+	for seed := int64(0); seed < 200; seed++ {
+		want, err := hedge.RandomParseInto(fstree.NewParser(fstreetest.NewFS(), fstree.WithContents()), rand.New(rand.NewSource(seed)), 10, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Init(buf)
+		got, err := hedge.RandomParseInto(p, rand.New(rand.NewSource(seed)), 10, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("seed %d: want %v, but got %v", seed, want, got)
+		}
+	}
+}
+
+func TestRecorderRecordsSkipped(t *testing.T) {
+	want := record(t)
+	for seed := int64(0); seed < 100; seed++ {
+		r := NewRecorder(fstree.NewParser(fstreetest.NewFS(), fstree.WithContents()))
+		if err := debug.RandomWalk(r, rand.New(rand.NewSource(seed)), 1000, 2); err != nil {
+			t.Fatal(err)
+		}
+		// Finish the walk, since RandomWalk returns when Skip reaches the end.
+		if err := debug.Walk(r); err != nil {
+			t.Fatal(err)
+		}
+		got, err := r.Bytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("seed %d: want the whole token stream to be recorded", seed)
+		}
+	}
+}
+
+func TestCheckSpans(t *testing.T) {
+	if err := debug.CheckSpans(NewParser(), record(t)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRawToken(t *testing.T) {
+	enc := &Encoder{}
+	enc.Enter(jsonschema.JSONSchemaTypeArray)
+	enc.Value(parse.StringKind, []byte("a"))
+	expect.NoErr(t, enc.Leave)
+	buf, err := enc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser()
+	p.Init(buf)
+	var got []byte
+	for {
+		if _, err := p.Next(); err != nil {
+			break
+		}
+		raw, err := p.(parse.RawTokenAble).RawToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, raw...)
+	}
+	if !bytes.Equal(got, buf) {
+		t.Fatalf("want the raw tokens to reproduce %v, but got %v", buf, got)
+	}
+}
+
+func TestRawSubtree(t *testing.T) {
+	want := record(t)
+	p := NewParser()
+	p.Init(want)
+	expect.Hint(t, p, parse.EnterHint)
+	got, err := parse.RawSubtree(p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("want the whole token stream, but got %v", got)
+	}
+	expect.EOF(t, p)
+
+	// fstree does not implement RawSubtreeAble, so the subtree is recorded by the fallback.
+	f := fstree.NewParser(fstreetest.NewFS(), fstree.WithContents())
+	expect.Hint(t, f, parse.EnterHint)
+	got, err = parse.RawSubtree(f, RecordSubtree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("want the fallback to reproduce the token stream, but got %v", got)
+	}
+	expect.EOF(t, f)
+}
+
+func TestLen(t *testing.T) {
+	enc := &Encoder{}
+	enc.Enter(jsonschema.JSONSchemaTypeObject)
+	enc.Field(parse.StringKind, []byte("a"))
+	enc.Enter(jsonschema.JSONSchemaTypeArray)
+	enc.Value(parse.NullKind, nil)
+	enc.Enter(jsonschema.JSONSchemaTypeObject)
+	expect.NoErr(t, enc.Leave)
+	enc.Value(parse.TrueKind, nil)
+	expect.NoErr(t, enc.Leave)
+	enc.Field(parse.StringKind, []byte("b"))
+	enc.Value(parse.FalseKind, nil)
+	expect.NoErr(t, enc.Leave)
+	buf, err := enc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser()
+	p.Init(buf)
+	expectLen := func(want int) {
+		t.Helper()
+		got, ok := parse.GetLen(p)
+		if !ok || got != want {
+			t.Fatalf("want length %d, but got %d, %v", want, got, ok)
+		}
+	}
+	expect.Hint(t, p, parse.EnterHint)
+	expectLen(2)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Hint(t, p, parse.EnterHint)
+	expectLen(3)
+	expect.Hint(t, p, parse.ValueHint)
+	if _, ok := parse.GetLen(p); ok {
+		t.Fatal("expected no length after a value")
+	}
+	expect.Hint(t, p, parse.EnterHint)
+	expectLen(0)
+
+	p.Init(buf)
+	h, err := hedge.ParseInto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if children := h[0].Children; cap(children) != 3 {
+		t.Fatalf("want the children of the array to be preallocated, but got capacity %d", cap(children))
+	}
+}
+
+// noSkipTo hides the SkipTo method of the parser.
+type noSkipTo struct {
+	Parser
+}
+
+func TestFind(t *testing.T) {
+	buf := record(t)
+	for _, accelerated := range []bool{true, false} {
+		var p parse.ParserWithInit = NewParser()
+		if !accelerated {
+			p = noSkipTo{NewParser()}
+		}
+		p.Init(buf)
+		hint, found, err := parse.Find(p, "docs", "a", "b.txt", "contents")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !found || hint != parse.ValueHint {
+			t.Fatalf("want to find a value, but got %v %c", found, hint)
+		}
+		kind, value, err := p.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind != parse.BytesKind || string(value) != "b" {
+			t.Fatalf("want contents b, but got %c %q", kind, value)
+		}
+
+		p.Init(buf)
+		if _, found, err := parse.Find(p, "bin", "missing"); err != nil || found {
+			t.Fatalf("want not found, but got %v, %v", found, err)
+		}
+		// The object that did not contain the field was left.
+		expect.Hint(t, p, parse.FieldHint)
+		expect.String(t, p, "docs")
+	}
+}
+
+func TestSkipToNotObject(t *testing.T) {
+	enc := &Encoder{}
+	enc.Enter(jsonschema.JSONSchemaTypeArray)
+	enc.Value(parse.NullKind, nil)
+	expect.NoErr(t, enc.Leave)
+	buf, err := enc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, accelerated := range []bool{true, false} {
+		var p parse.ParserWithInit = NewParser()
+		if !accelerated {
+			p = noSkipTo{NewParser()}
+		}
+		p.Init(buf)
+		expect.Hint(t, p, parse.EnterHint)
+		if _, err := parse.SkipTo(p, []byte("a")); !errors.Is(err, parse.ErrNotObject) {
+			t.Fatalf("want ErrNotObject, but got %v", err)
+		}
+	}
+}
+
+func TestFindIndex(t *testing.T) {
+	enc := &Encoder{}
+	enc.Enter(jsonschema.JSONSchemaTypeArray)
+	enc.Value(parse.NullKind, nil)
+	enc.Enter(jsonschema.JSONSchemaTypeObject)
+	enc.Field(parse.StringKind, []byte("a"))
+	enc.Value(parse.FalseKind, nil)
+	expect.NoErr(t, enc.Leave)
+	enc.Enter(jsonschema.JSONSchemaTypeObject)
+	enc.Field(parse.StringKind, []byte("a"))
+	enc.Value(parse.TrueKind, nil)
+	expect.NoErr(t, enc.Leave)
+	expect.NoErr(t, enc.Leave)
+	buf, err := enc.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewParser()
+	p.Init(buf)
+	hint, found, err := parse.Find(p, "2", "a")
+	if err != nil || !found || hint != parse.ValueHint {
+		t.Fatalf("want to find a value, but got %c, %v, %v", hint, found, err)
+	}
+	expect.True(t, p)
+	p.Init(buf)
+	if _, found, err := parse.Find(p, "3"); err != nil || found {
+		t.Fatalf("want not found, but got %v, %v", found, err)
+	}
+}
@@ -0,0 +1,27 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package rewind
+
+import "katydid.org.za/go/parser-go/pool"
+
+// Option is used set options when creating a new rewind Parser.
+type Option func(*parser)
+
+// WithPool replaces the default pool.New() pool, which is used to copy the tokens that are recorded between a Mark and its Release.
+func WithPool(pool pool.Pool) func(*parser) {
+	return func(p *parser) {
+		p.pool = pool
+	}
+}
@@ -0,0 +1,421 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package rewind wraps a parse.Parser to allow marking a position and rewinding to it later.
+// This allows a consumer to try one interpretation of a subtree and fall back to another.
+package rewind
+
+import (
+	"io"
+
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/pool"
+)
+
+// Parser is a parser that can rewind to a marked position.
+type Parser interface {
+	parse.ParserWithInit
+	jsonschema.JSONSchemaAble
+	Reset()
+	// Mark marks the current position.
+	// Marks are nested, which means a later mark has to be released before an earlier mark.
+	Mark() Marker
+	// Rewind rewinds the parser to the marked position and releases all marks that were made after it.
+	// The marker stays active, so that it can be rewound to again.
+	// After Rewind the current token is the token that was current when Mark was called,
+	// except when the parser was rewound by calling Init at an offset, in which case there is no current token.
+	Rewind(m Marker) error
+	// Release releases the mark and all marks that were made after it.
+	// Recorded tokens are freed once all marks are released and the recorded tokens have been replayed.
+	Release(m Marker) error
+}
+
+// Marker is returned by Mark and identifies a marked position.
+type Marker struct {
+	level int
+	gen   uint64
+}
+
+type event struct {
+	hint  parse.Hint
+	kind  parse.Kind
+	value []byte
+	err   error
+	typ   jsonschema.JSONSchemaType
+}
+
+type mark struct {
+	gen uint64
+	// fast marks rewind by calling Init at an offset, instead of replaying recorded tokens.
+	fast bool
+	// offset is the offset of the token after a fast mark, or -1 if it is not known yet.
+	offset int64
+	// index is the absolute index of the recorded event after a mark.
+	index int64
+	// cur is a copy of the current event when the mark was made.
+	cur   event
+	depth int
+}
+
+type parser struct {
+	p       parse.Parser
+	init    parse.ParserWithInit
+	restart parse.RestartAble
+	able    jsonschema.JSONSchemaAble
+	pool    pool.Pool
+
+	// buf is the buffer passed to Init, which is used to rewind by calling Init at an offset.
+	buf []byte
+	// base is the offset in buf at which the wrapped parser was last initialized.
+	base int64
+	// pending is true if there are fast marks that are waiting for the offset of the next token.
+	pending bool
+
+	// log is the recorded events, while there are marks that are not fast.
+	log []event
+	// logBase is the absolute index of the first event in log.
+	logBase int64
+	// pos is the index of the next event in log to replay, which is len(log) when not replaying.
+	pos int
+	// slow is the number of active marks that are not fast.
+	slow  int
+	marks []mark
+	gen   uint64
+
+	cur event
+	// live is true if the current event is the current event of the wrapped parser.
+	live  bool
+	depth int
+}
+
+// NewParser returns a parser that can rewind to marked positions.
+// Tokens are recorded into a pool, between a mark and its release, so memory use is bounded by the size of the marked region.
+// Skip is passed through to p, unless tokens are being recorded or replayed,
+// in which case it is emulated by calling Next.
+//
+// If p implements parse.ParserWithInit and parse.RestartAble and the returned parser was initialized using Init,
+// then a mark outside of any object or array does not record tokens,
+// but rewinds by calling Init on p with the input starting at the marked offset.
+func NewParser(p parse.Parser, opts ...Option) Parser {
+	r := &parser{
+		p:     p,
+		pool:  pool.New(),
+		log:   make([]event, 0, 10),
+		marks: make([]mark, 0, 10),
+	}
+	r.init, _ = p.(parse.ParserWithInit)
+	r.restart, _ = p.(parse.RestartAble)
+	r.able, _ = p.(jsonschema.JSONSchemaAble)
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Init initializes the wrapped parser, if it implements parse.ParserWithInit.
+func (p *parser) Init(buf []byte) {
+	p.buf = buf
+	p.base = 0
+	if p.init != nil {
+		p.init.Init(buf)
+	}
+	p.clear()
+}
+
+func (p *parser) Reset() {
+	if p.buf != nil && p.init != nil {
+		// The wrapped parser might have been initialized at an offset.
+		p.Init(p.buf)
+		return
+	}
+	if r, ok := p.p.(interface{ Reset() }); ok {
+		r.Reset()
+	}
+	p.clear()
+}
+
+func (p *parser) clear() {
+	p.marks = p.marks[:0]
+	p.slow = 0
+	p.pending = false
+	p.cur = event{}
+	p.live = false
+	p.free()
+	p.depth = 0
+}
+
+// free frees the recorded events.
+func (p *parser) free() {
+	if !p.live && p.cur.value != nil {
+		// The current value was replayed or rewound to, so it could be in the pool and has to be copied out, before the pool is freed.
+		p.cur.value = append([]byte(nil), p.cur.value...)
+	}
+	p.logBase += int64(len(p.log))
+	p.log = p.log[:0]
+	p.pos = 0
+	p.pool.FreeAll()
+}
+
+func (p *parser) replaying() bool {
+	return p.pos < len(p.log)
+}
+
+// copy returns a copy of the current event, where the token is copied into the pool.
+func (p *parser) copy() event {
+	e := p.cur
+	if !p.live {
+		if e.value != nil {
+			value := p.pool.Alloc(len(e.value))
+			copy(value, e.value)
+			e.value = value
+		}
+		return e
+	}
+	switch e.hint {
+	case parse.FieldHint, parse.ValueHint:
+		kind, value, err := p.p.Token()
+		e.kind = kind
+		e.value = p.pool.Alloc(len(value))
+		copy(e.value, value)
+		e.err = err
+	case parse.EnterHint:
+		if p.able != nil {
+			e.typ = p.able.JSONSchemaType()
+		}
+	}
+	return e
+}
+
+func (p *parser) fastAble() bool {
+	return p.init != nil && p.restart != nil && p.buf != nil && p.depth == 0 && p.slow == 0 && !p.replaying()
+}
+
+func (p *parser) Mark() Marker {
+	p.gen++
+	m := mark{gen: p.gen, depth: p.depth, offset: -1}
+	if p.fastAble() {
+		m.fast = true
+		p.pending = true
+	} else {
+		if p.slow == 0 && !p.replaying() {
+			p.free()
+		}
+		p.slow++
+		m.index = p.logBase + int64(p.pos)
+		m.cur = p.copy()
+		if !p.live {
+			// The current value might be in memory that was freed.
+			p.cur.value = m.cur.value
+		}
+	}
+	p.marks = append(p.marks, m)
+	return Marker{level: len(p.marks) - 1, gen: m.gen}
+}
+
+func (p *parser) valid(m Marker) bool {
+	return m.level < len(p.marks) && p.marks[m.level].gen == m.gen
+}
+
+// release releases all marks from the given level.
+func (p *parser) release(level int) {
+	for _, m := range p.marks[level:] {
+		if !m.fast {
+			p.slow--
+		}
+	}
+	p.marks = p.marks[:level]
+	p.pending = false
+	for _, m := range p.marks {
+		if m.fast && m.offset < 0 {
+			p.pending = true
+		}
+	}
+}
+
+func (p *parser) Release(m Marker) error {
+	if !p.valid(m) {
+		return errInvalidMarker
+	}
+	p.release(m.level)
+	if p.slow == 0 && !p.replaying() {
+		p.free()
+	}
+	return nil
+}
+
+func (p *parser) Rewind(marker Marker) error {
+	if !p.valid(marker) {
+		return errInvalidMarker
+	}
+	p.release(marker.level + 1)
+	m := &p.marks[marker.level]
+	if !m.fast {
+		p.pos = int(m.index - p.logBase)
+		p.cur = m.cur
+		p.depth = m.depth
+		p.live = false
+		return nil
+	}
+	if m.offset < 0 {
+		if p.pending {
+			// Next has not been called since the mark was made, so the wrapped parser has not moved.
+			return nil
+		}
+		return errNoOffset
+	}
+	// All recorded tokens were recorded after the fast mark, since they were released.
+	p.cur = event{}
+	p.live = false
+	p.free()
+	p.depth = m.depth
+	p.base = m.offset
+	p.init.Init(p.buf[m.offset:])
+	return nil
+}
+
+func (p *parser) Next() (parse.Hint, error) {
+	if p.replaying() {
+		p.cur = p.log[p.pos]
+		p.pos++
+		p.live = false
+		if p.slow == 0 && !p.replaying() {
+			p.free()
+		}
+	} else {
+		h, err := p.p.Next()
+		if p.pending {
+			p.resolve(err)
+		}
+		if err != nil {
+			p.cur = event{}
+			p.live = false
+			return parse.UnknownHint, err
+		}
+		p.cur = event{hint: h}
+		p.live = true
+		if p.slow > 0 {
+			p.log = append(p.log, p.copy())
+			p.pos = len(p.log)
+		}
+	}
+	switch p.cur.hint {
+	case parse.EnterHint:
+		p.depth++
+	case parse.LeaveHint:
+		p.depth--
+	}
+	return p.cur.hint, nil
+}
+
+// resolve sets the offset of the fast marks that are waiting for the offset of the next token.
+func (p *parser) resolve(err error) {
+	p.pending = false
+	offset := int64(-1)
+	if err == io.EOF {
+		offset = int64(len(p.buf))
+	} else if err == nil {
+		if o := p.restart.RestartOffset(); o >= 0 {
+			offset = p.base + o
+		}
+	}
+	for i := range p.marks {
+		if p.marks[i].fast && p.marks[i].offset < 0 {
+			p.marks[i].offset = offset
+		}
+	}
+}
+
+// native returns whether Skip can be passed through to the wrapped parser.
+func (p *parser) native() bool {
+	return p.live && p.slow == 0 && !p.replaying() && !p.pending
+}
+
+func (p *parser) Skip() error {
+	var err error
+	switch p.cur.hint {
+	case parse.EnterHint:
+		// Skip the whole object or array.
+		err = p.leave(p.depth - 1)
+	case parse.FieldHint:
+		// Skip the field's value.
+		if p.native() {
+			err = p.p.Skip()
+		} else {
+			var h parse.Hint
+			h, err = p.Next()
+			if err == nil && h == parse.EnterHint {
+				err = p.leave(p.depth - 1)
+			}
+		}
+	case parse.ValueHint:
+		// Skip the rest of the object or array.
+		if p.depth > 0 {
+			err = p.leave(p.depth - 1)
+		}
+	default:
+		_, err = p.Next()
+		return err
+	}
+	p.cur = event{}
+	p.live = false
+	return err
+}
+
+// leave calls Next until the depth is reduced to the given depth.
+// If no tokens need to be recorded or replayed, then the wrapped parser's Skip is used.
+func (p *parser) leave(depth int) error {
+	for p.depth > depth {
+		if p.native() {
+			switch p.cur.hint {
+			case parse.EnterHint, parse.ValueHint:
+				// Both skip to the end of the current object or array.
+				if err := p.p.Skip(); err != nil {
+					return err
+				}
+				p.depth--
+				p.cur = event{}
+				continue
+			}
+		}
+		if _, err := p.Next(); err != nil {
+			if err == io.EOF {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) Token() (parse.Kind, []byte, error) {
+	if p.live {
+		return p.p.Token()
+	}
+	switch p.cur.hint {
+	case parse.FieldHint, parse.ValueHint:
+		return p.cur.kind, p.cur.value, p.cur.err
+	}
+	return parse.UnknownKind, nil, nil
+}
+
+func (p *parser) JSONSchemaType() jsonschema.JSONSchemaType {
+	if p.cur.hint != parse.EnterHint {
+		return jsonschema.JSONSchemaTypeUnknown
+	}
+	if p.live && p.able != nil {
+		return p.able.JSONSchemaType()
+	}
+	return p.cur.typ
+}
@@ -0,0 +1,265 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package rewind
+
+import (
+	"math/rand"
+	"testing"
+
+	"katydid.org.za/go/parser-go/expect"
+	"katydid.org.za/go/parser-go/hedge"
+	"katydid.org.za/go/parser-go/internal/replaytest"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/pool"
+	"katydid.org.za/go/parser-go/replay"
+)
+
+// encode returns a token stream for `{"a": [1, {"b": true}], "c": "d"}`.
+func encode(t *testing.T) []byte {
+	t.Helper()
+	return replaytest.Encode(t, func(enc *replaytest.Encoder) {
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("a"))
+		enc.Enter(jsonschema.JSONSchemaTypeArray)
+		enc.Value(parse.Int64Kind, replaytest.Int64(1))
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("b"))
+		enc.Value(parse.TrueKind, nil)
+		enc.Leave()
+		enc.Leave()
+		enc.Field(parse.StringKind, []byte("c"))
+		enc.Value(parse.StringKind, []byte("d"))
+		enc.Leave()
+	})
+}
+
+// counting counts the calls to Init and reports restart offsets.
+type counting struct {
+	replay.Parser
+	inits int
+}
+
+func (c *counting) Init(buf []byte) {
+	c.inits++
+	c.Parser.Init(buf)
+}
+
+func (c *counting) RestartOffset() int64 {
+	return c.Parser.(parse.RestartAble).RestartOffset()
+}
+
+// countingPool counts the allocations.
+type countingPool struct {
+	pool.Pool
+	allocs int
+}
+
+func (c *countingPool) Alloc(size int) []byte {
+	c.allocs++
+	return c.Pool.Alloc(size)
+}
+
+func TestRewind(t *testing.T) {
+	p := NewParser(replay.NewParser())
+	p.Init(encode(t))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	m := p.Mark()
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "c")
+	expect.NoErr(t, func() error { return p.Rewind(m) })
+	// The current token is the token that was current when Mark was called.
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.EnterHint)
+	if typ := p.JSONSchemaType(); typ != jsonschema.JSONSchemaTypeArray {
+		t.Fatalf("want array, but got %c", typ)
+	}
+	expect.NoErr(t, func() error { return p.Release(m) })
+	if err := p.Rewind(m); err == nil {
+		t.Fatal("expected error for rewinding to a released marker")
+	}
+	// Skip the array, while replaying the recorded tokens.
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "c")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "d")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestNestedMarks(t *testing.T) {
+	p := NewParser(replay.NewParser())
+	p.Init(encode(t))
+	expect.Hint(t, p, parse.EnterHint)
+	outer := p.Mark()
+	expect.Hint(t, p, parse.FieldHint)
+	inner := p.Mark()
+	expect.Hint(t, p, parse.EnterHint)
+	expect.NoErr(t, func() error { return p.Rewind(outer) })
+	if err := p.Release(inner); err == nil {
+		t.Fatal("expected error for releasing a mark that was made after the rewound mark")
+	}
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.NoErr(t, func() error { return p.Release(outer) })
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "c")
+}
+
+func TestReleaseAfterRewind(t *testing.T) {
+	p := NewParser(replay.NewParser(), WithPool(pool.New(pool.WithPoison())))
+	p.Init(encode(t))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	m := p.Mark()
+	expect.NoErr(t, func() error { return p.Rewind(m) })
+	expect.NoErr(t, func() error { return p.Release(m) })
+	// The current token was recorded by the mark and has to survive the release of the recorded tokens.
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.EnterHint)
+}
+
+func TestRewindInit(t *testing.T) {
+	buf := encode(t)
+	c := &counting{Parser: replay.NewParser()}
+	alloc := &countingPool{Pool: pool.New()}
+	p := NewParser(c, WithPool(alloc))
+	p.Init(buf)
+	m := p.Mark()
+	want, err := hedge.ParseInto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.NoErr(t, func() error { return p.Rewind(m) })
+	got, err := hedge.ParseInto(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+	if c.inits != 2 {
+		t.Fatalf("want rewind to call Init, but Init was called %d times", c.inits)
+	}
+	if alloc.allocs != 0 {
+		t.Fatalf("want no tokens to be recorded, but got %d allocations", alloc.allocs)
+	}
+}
+
+func TestRewindInitValues(t *testing.T) {
+	// A token stream of top level values, where the offset of a value is after the start of its event.
+	buf := replaytest.Encode(t, func(enc *replaytest.Encoder) {
+		enc.Value(parse.StringKind, []byte("a"))
+		enc.Value(parse.StringKind, []byte("b"))
+		enc.Value(parse.StringKind, []byte("c"))
+	})
+	c := &counting{Parser: replay.NewParser()}
+	p := NewParser(c)
+	p.Init(buf)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "a")
+	m := p.Mark()
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "c")
+	expect.NoErr(t, func() error { return p.Rewind(m) })
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "c")
+	expect.EOF(t, p)
+	if c.inits != 2 {
+		t.Fatalf("want rewind to call Init, but Init was called %d times", c.inits)
+	}
+}
+
+// randomRewinder randomly explores ahead, before every call to Next and Skip, and then rewinds.
+type randomRewinder struct {
+	Parser
+	t *testing.T
+	r *rand.Rand
+}
+
+func (p *randomRewinder) explore(level int) {
+	if p.r.Intn(3) != 0 {
+		return
+	}
+	m := p.Mark()
+	for i := p.r.Intn(5); i > 0; i-- {
+		if level < 2 {
+			p.explore(level + 1)
+		}
+		var err error
+		if p.r.Intn(3) == 0 {
+			err = p.Parser.Skip()
+		} else {
+			_, err = p.Parser.Next()
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err := p.Rewind(m); err != nil {
+		p.t.Fatal(err)
+	}
+	if err := p.Release(m); err != nil {
+		p.t.Fatal(err)
+	}
+}
+
+func (p *randomRewinder) Next() (parse.Hint, error) {
+	p.explore(0)
+	return p.Parser.Next()
+}
+
+func (p *randomRewinder) Skip() error {
+	p.explore(0)
+	return p.Parser.Skip()
+}
+
+func TestRandomRewind(t *testing.T) {
+	buf := encode(t)
+	for seed := int64(0); seed < 500; seed++ {
+		r := replay.NewParser()
+		r.Init(buf)
+		want, err := hedge.RandomParseInto(r, rand.New(rand.NewSource(seed)), 10, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var p Parser
+		if seed%2 == 0 {
+			// Hide the RestartOffset method, so that all marks record tokens.
+			p = NewParser(struct{ replay.Parser }{replay.NewParser()})
+		} else {
+			p = NewParser(&counting{Parser: replay.NewParser()})
+		}
+		p.Init(buf)
+		got, err := hedge.RandomParseInto(&randomRewinder{p, t, rand.New(rand.NewSource(-seed))}, rand.New(rand.NewSource(seed)), 10, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("seed %d: want %v, but got %v", seed, want, got)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package symbol interns field names into a Table and identifies them with small integers, see parse.FieldIDAble.
+package symbol
+
+import (
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// Parser is a parser that identifies fields with IDs from a Table.
+type Parser interface {
+	parse.Parser
+	parse.FieldIDAble
+	jsonschema.JSONSchemaAble
+	Reset()
+}
+
+type parser struct {
+	p         parse.Parser
+	table     *Table
+	knownOnly bool
+	hint      parse.Hint
+	// id is the ID of the current field, which is looked up when FieldID is first called.
+	id     int
+	idOk   bool
+	idDone bool
+}
+
+// NewParser returns a parser that interns the field names of p into the table and returns their IDs from FieldID.
+// The IDs are always the Table's IDs, even if p implements parse.FieldIDAble,
+// since the IDs of p, for example protobuf field numbers, are not names and can repeat in different messages.
+// A parser that supplies its own IDs can be used without wrapping it.
+func NewParser(p parse.Parser, table *Table, opts ...Option) Parser {
+	s := &parser{
+		p:     p,
+		table: table,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *parser) Next() (parse.Hint, error) {
+	h, err := s.p.Next()
+	s.hint = h
+	s.idDone = false
+	return h, err
+}
+
+func (s *parser) Skip() error {
+	s.hint = parse.UnknownHint
+	s.idDone = false
+	return s.p.Skip()
+}
+
+func (s *parser) Token() (parse.Kind, []byte, error) {
+	return s.p.Token()
+}
+
+// FieldID returns the ID of the current field's name in the table.
+func (s *parser) FieldID() (int, bool) {
+	if s.hint != parse.FieldHint {
+		return 0, false
+	}
+	if !s.idDone {
+		s.id, s.idOk = s.lookup()
+		s.idDone = true
+	}
+	return s.id, s.idOk
+}
+
+func (s *parser) lookup() (int, bool) {
+	kind, name, err := s.p.Token()
+	if err != nil || (kind != parse.StringKind && kind != parse.BytesKind) {
+		return 0, false
+	}
+	if s.knownOnly {
+		return s.table.Lookup(name)
+	}
+	return s.table.Intern(name), true
+}
+
+func (s *parser) JSONSchemaType() jsonschema.JSONSchemaType {
+	if j, ok := s.p.(jsonschema.JSONSchemaAble); ok {
+		return j.JSONSchemaType()
+	}
+	return jsonschema.JSONSchemaTypeUnknown
+}
+
+func (s *parser) Reset() {
+	if r, ok := s.p.(interface{ Reset() }); ok {
+		r.Reset()
+	}
+	s.hint = parse.UnknownHint
+	s.idDone = false
+}
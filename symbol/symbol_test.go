@@ -0,0 +1,109 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package symbol
+
+import (
+	"io"
+	"testing"
+
+	"katydid.org.za/go/parser-go/expect"
+	"katydid.org.za/go/parser-go/fstree"
+	"katydid.org.za/go/parser-go/internal/fstreetest"
+	"katydid.org.za/go/parser-go/parse"
+)
+
+func expectID(t *testing.T, p Parser, want int) {
+	t.Helper()
+	got, ok := p.FieldID()
+	if !ok || got != want {
+		t.Fatalf("want field ID %d, but got %d, %v", want, got, ok)
+	}
+}
+
+func TestIntern(t *testing.T) {
+	table := NewTable("size", "mode")
+	p := NewParser(fstree.NewParser(fstreetest.NewFS()), table)
+	ids := map[string]int{}
+	for {
+		hint, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hint != parse.FieldHint {
+			continue
+		}
+		_, name, err := p.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, ok := p.FieldID()
+		if !ok {
+			t.Fatalf("want an ID for %s", name)
+		}
+		if prev, ok := ids[string(name)]; ok && prev != id {
+			t.Fatalf("want a stable ID for %s, but got %d and %d", name, prev, id)
+		}
+		ids[string(name)] = id
+	}
+	if ids["size"] != 0 || ids["mode"] != 1 {
+		t.Fatalf("want pre-registered IDs, but got %v", ids)
+	}
+	if table.Len() != len(ids) {
+		t.Fatalf("want %d names in the table, but got %d", len(ids), table.Len())
+	}
+	if name, ok := table.Name(ids["README.md"]); !ok || name != "README.md" {
+		t.Fatalf("want README.md, but got %q", name)
+	}
+}
+
+func TestKnownOnly(t *testing.T) {
+	table := NewTable("README.md")
+	p := NewParser(fstree.NewParser(fstreetest.NewFS()), table, WithKnownOnly())
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expectID(t, p, 0)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	if _, ok := p.FieldID(); ok {
+		t.Fatal("want no ID for an unknown name")
+	}
+	expect.Hint(t, p, parse.ValueHint)
+	if _, ok := p.FieldID(); ok {
+		t.Fatal("want no ID for a value")
+	}
+	if table.Len() != 1 {
+		t.Fatalf("want the table to not grow, but got %d names", table.Len())
+	}
+}
+
+// numbered is a parser that supplies its own field IDs.
+type numbered struct {
+	fstree.Parser
+}
+
+func (numbered) FieldID() (int, bool) {
+	return 42, true
+}
+
+func TestTableIDsOnly(t *testing.T) {
+	table := NewTable("README.md")
+	p := NewParser(numbered{fstree.NewParser(fstreetest.NewFS())}, table)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expectID(t, p, 0)
+}
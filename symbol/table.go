@@ -0,0 +1,73 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package symbol
+
+// Table interns field names and assigns each name a stable small integer ID, starting at zero.
+// A Table is not safe for concurrent use, unless it is only read, for example when used with WithKnownOnly.
+type Table struct {
+	ids   map[string]int
+	names []string
+}
+
+// NewTable returns a table with the given names pre-registered, in order, so that the first name has ID 0.
+func NewTable(names ...string) *Table {
+	t := &Table{
+		ids:   make(map[string]int, len(names)),
+		names: make([]string, 0, len(names)),
+	}
+	for _, name := range names {
+		t.Register(name)
+	}
+	return t
+}
+
+// Register returns the ID of the name, registering it if it has not been registered.
+func (t *Table) Register(name string) int {
+	if id, ok := t.ids[name]; ok {
+		return id
+	}
+	id := len(t.names)
+	t.ids[name] = id
+	t.names = append(t.names, name)
+	return id
+}
+
+// Lookup returns the ID of the name, if it has been registered.
+// Lookup does not allocate.
+func (t *Table) Lookup(name []byte) (int, bool) {
+	id, ok := t.ids[string(name)]
+	return id, ok
+}
+
+// Intern returns the ID of the name, registering a copy of the name if it has not been registered.
+func (t *Table) Intern(name []byte) int {
+	if id, ok := t.ids[string(name)]; ok {
+		return id
+	}
+	return t.Register(string(name))
+}
+
+// Name returns the name of the ID, or false if the ID has not been assigned.
+func (t *Table) Name(id int) (string, bool) {
+	if id < 0 || id >= len(t.names) {
+		return "", false
+	}
+	return t.names[id], true
+}
+
+// Len returns the number of registered names.
+func (t *Table) Len() int {
+	return len(t.names)
+}
@@ -14,10 +14,37 @@
 
 package tag
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var errUnexpectedClose = errors.New("unexpected `}` or `]`")
 
 var errExpectedTag = errors.New("expected tag")
 
 var errUnknownJSONSchemaType = errors.New("unknown json schema type")
+
+var errUnknownValueType = errors.New("unknown json schema type for value")
+
+// DuplicateLabelError is returned by Next and Skip, when more than one tag in use has the same label, see WithLabels.
+type DuplicateLabelError struct {
+	Label string
+}
+
+func (e *DuplicateLabelError) Error() string {
+	return fmt.Sprintf("tag label %q is used for more than one tag", e.Label)
+}
+
+// CollisionError is returned by Next, when a field name collides with a label or the prefix of the tags, see WithStrict.
+type CollisionError struct {
+	Name   string
+	Prefix string
+}
+
+func (e *CollisionError) Error() string {
+	if e.Prefix != "" {
+		return fmt.Sprintf("field name %q collides with the reserved tag prefix %q", e.Name, e.Prefix)
+	}
+	return fmt.Sprintf("field name %q collides with a tag label", e.Name)
+}
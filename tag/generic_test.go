@@ -0,0 +1,175 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package tag
+
+import (
+	"io"
+	"testing"
+
+	"katydid.org.za/go/parser-go/hedge"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/parse/debug"
+)
+
+type stubState struct {
+	n     int
+	pos   int
+	value [8]byte
+}
+
+// stub is a value type parser, which parses an object with n fields,
+// so that generic functions are instantiated for it, instead of an interface.
+type stub struct {
+	s *stubState
+}
+
+func newStub(n int) stub {
+	return stub{&stubState{n: n}}
+}
+
+func (p stub) hint() parse.Hint {
+	switch {
+	case p.s.pos == 0:
+		return parse.UnknownHint
+	case p.s.pos == 1:
+		return parse.EnterHint
+	case p.s.pos == 2*p.s.n+2:
+		return parse.LeaveHint
+	case p.s.pos%2 == 0:
+		return parse.FieldHint
+	}
+	return parse.ValueHint
+}
+
+func (p stub) Next() (parse.Hint, error) {
+	if p.s.pos > 2*p.s.n+1 {
+		return parse.UnknownHint, io.EOF
+	}
+	p.s.pos++
+	return p.hint(), nil
+}
+
+func (p stub) Skip() error {
+	switch p.hint() {
+	case parse.EnterHint, parse.ValueHint:
+		p.s.pos = 2*p.s.n + 2
+	case parse.FieldHint:
+		p.s.pos++
+	default:
+		_, err := p.Next()
+		return err
+	}
+	return nil
+}
+
+var stubField = []byte("a")
+
+func (p stub) Token() (parse.Kind, []byte, error) {
+	switch p.hint() {
+	case parse.FieldHint:
+		return parse.StringKind, stubField, nil
+	case parse.ValueHint:
+		return parse.Int64Kind, p.s.value[:], nil
+	}
+	return parse.UnknownKind, nil, nil
+}
+
+func (p stub) JSONSchemaType() jsonschema.JSONSchemaType {
+	if p.hint() == parse.EnterHint {
+		return jsonschema.JSONSchemaTypeObject
+	}
+	return jsonschema.JSONSchemaTypeUnknown
+}
+
+func (p stub) Reset() {
+	p.s.pos = 0
+}
+
+func TestTaggerOf(t *testing.T) {
+	want, err := hedge.ParseInto(NewTagger(newStub(3), WithTags()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := hedge.ParseIntoOf(NewTaggerOf(newStub(3), WithTags()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(want) {
+		t.Fatalf("want %v, but got %v", want, got)
+	}
+}
+
+const stubFields = 1000
+
+func BenchmarkWalk(b *testing.B) {
+	p := newStub(stubFields)
+	for b.Loop() {
+		p.Reset()
+		if err := debug.Walk(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWalkOf(b *testing.B) {
+	p := newStub(stubFields)
+	for b.Loop() {
+		p.Reset()
+		if err := debug.WalkOf(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseInto(b *testing.B) {
+	p := newStub(stubFields)
+	for b.Loop() {
+		p.Reset()
+		if _, err := hedge.ParseInto(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseIntoOf(b *testing.B) {
+	p := newStub(stubFields)
+	for b.Loop() {
+		p.Reset()
+		if _, err := hedge.ParseIntoOf(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTagger(b *testing.B) {
+	t := NewTagger(newStub(stubFields), WithTags())
+	for b.Loop() {
+		t.Reset()
+		if err := debug.Walk(t); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTaggerOf(b *testing.B) {
+	t := NewTaggerOf(newStub(stubFields), WithTags())
+	for b.Loop() {
+		t.Reset()
+		if err := debug.Walk(t); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package tag
+
+import (
+	"bytes"
+
+	"katydid.org.za/go/parser-go/parse"
+)
+
+// Labels are the field names of the tags that the tagger inserts, see WithTags and WithValueTypes.
+type Labels struct {
+	Object  string
+	Array   string
+	Null    string
+	Boolean string
+	Integer string
+	Number  string
+	String  string
+}
+
+var defaultLabels = Labels{
+	Object:  "object",
+	Array:   "array",
+	Null:    "null",
+	Boolean: "boolean",
+	Integer: "integer",
+	Number:  "number",
+	String:  "string",
+}
+
+// LabelsOf returns the labels that a tagger, created with the same options, uses for its tags,
+// including the prefix, so that validators can be generated against them.
+func LabelsOf(opts ...Option) Labels {
+	o := newOptions(opts...)
+	return o.resolveLabels()
+}
+
+// resolveLabels replaces the empty labels with the default labels and adds the prefix.
+func (o *options) resolveLabels() Labels {
+	l := o.labels
+	resolve := func(label *string, def string) {
+		if *label == "" {
+			*label = def
+		}
+		*label = o.prefix + *label
+	}
+	resolve(&l.Object, defaultLabels.Object)
+	resolve(&l.Array, defaultLabels.Array)
+	resolve(&l.Null, defaultLabels.Null)
+	resolve(&l.Boolean, defaultLabels.Boolean)
+	resolve(&l.Integer, defaultLabels.Integer)
+	resolve(&l.Number, defaultLabels.Number)
+	resolve(&l.String, defaultLabels.String)
+	return l
+}
+
+// tokens are the labels as they are returned by the tagger's Token method.
+type tokens struct {
+	object  []byte
+	array   []byte
+	null    []byte
+	boolean []byte
+	integer []byte
+	number  []byte
+	string  []byte
+	// reserved are the labels that are in use, which field names may not collide with, see WithStrict.
+	reserved [][]byte
+}
+
+func newTokens(o *options) tokens {
+	l := o.resolveLabels()
+	t := tokens{
+		object:  []byte(l.Object),
+		array:   []byte(l.Array),
+		null:    []byte(l.Null),
+		boolean: []byte(l.Boolean),
+		integer: []byte(l.Integer),
+		number:  []byte(l.Number),
+		string:  []byte(l.String),
+	}
+	if o.tag {
+		t.reserved = append(t.reserved, t.object, t.array)
+	}
+	if o.valueTypes {
+		t.reserved = append(t.reserved, t.null, t.boolean, t.integer, t.number, t.string)
+	}
+	return t
+}
+
+// distinct returns a DuplicateLabelError, if two labels that are in use are the same,
+// since consumers would not be able to tell the tags apart.
+func (t *tokens) distinct() error {
+	for i, label := range t.reserved {
+		for _, other := range t.reserved[i+1:] {
+			if bytes.Equal(label, other) {
+				return &DuplicateLabelError{Label: string(label)}
+			}
+		}
+	}
+	return nil
+}
+
+// valueTypeToken returns the tag of the JSON Schema type of a value with the given kind.
+func (t *tokens) valueTypeToken(kind parse.Kind) ([]byte, error) {
+	switch kind {
+	case parse.NullKind:
+		return t.null, nil
+	case parse.FalseKind, parse.TrueKind:
+		return t.boolean, nil
+	case parse.Int64Kind, parse.Uint64Kind, parse.BigIntKind, parse.NanosecondsKind:
+		return t.integer, nil
+	case parse.Float64Kind, parse.DecimalKind:
+		return t.number, nil
+	case parse.StringKind, parse.BytesKind, parse.DateTimeKind, parse.TagKind:
+		return t.string, nil
+	}
+	return nil, errUnknownValueType
+}
@@ -14,32 +14,99 @@
 
 package tag
 
+import "katydid.org.za/go/parser-go/pool"
+
 // Option is used set options when creating a new JSON Parser.
-type Option func(*tagger)
+type Option func(*options)
+
+type options struct {
+	tag        bool
+	index      bool
+	valueTypes bool
+	labels     Labels
+	prefix     string
+	strict     bool
+	alloc      func(size int) []byte
+}
+
+func newOptions(opts ...Option) options {
+	o := options{
+		alloc: func(size int) []byte {
+			return make([]byte, size)
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
 
 // WithTags tags
 // 1. each object with an object key, for example `{"a": null}` is parsed as `{"object": {"a": null}}`.
 // 2. each array with an array key, for example `{"a": []}` is parsed as `{"a": {"array": []}}`.
-func WithTags() func(*tagger) {
-	return func(t *tagger) {
-		t.tag = true
+func WithTags() func(*options) {
+	return func(o *options) {
+		o.tag = true
 	}
 }
 
 // WithIndexes tags each array item with an index:
 // for example `["a", "b"]` is parsed as `[0: "a", 1: "b"]`.
 // Requires WithTags to also be passed as an option.
-func WithIndexes() func(*tagger) {
-	return func(t *tagger) {
-		t.index = true
+func WithIndexes() func(*options) {
+	return func(o *options) {
+		o.index = true
+	}
+}
+
+// WithValueTypes tags each value with its JSON Schema type, which is one of null, boolean, integer, number or string,
+// for example `{"a": 1}` is parsed as `{"a": {"integer": 1}}`.
+// The type is derived from the parse.Kind of the value.
+// Bytes, DateTime and Tag kinds are strings, Uint64, BigInt and Nanoseconds kinds are integers and Decimal kinds are numbers.
+func WithValueTypes() func(*options) {
+	return func(o *options) {
+		o.valueTypes = true
+	}
+}
+
+// WithLabels replaces the default labels of the tags, for example `Labels{Object: "$obj"}`.
+// Empty labels keep their default, see LabelsOf.
+// The labels of the tags that are in use have to be distinct, otherwise Next and Skip return a DuplicateLabelError.
+func WithLabels(labels Labels) func(*options) {
+	return func(o *options) {
+		o.labels = labels
+	}
+}
+
+// WithPrefix reserves a namespace for the tags, by prefixing all labels,
+// for example WithPrefix("@") tags objects with "@object".
+func WithPrefix(prefix string) func(*options) {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithStrict returns a CollisionError from Next, when a field name of the tagged parser collides with a label that is in use,
+// or starts with the prefix, if WithPrefix is used.
+func WithStrict() func(*options) {
+	return func(o *options) {
+		o.strict = true
 	}
 }
 
 // WithAllocator replaces the default `func(size int) []byte { return make([]byte, size) }` allocator
 // with a different allocator function.
 // Usually an allocator that uses a pool.
-func WithAllocator(alloc func(int) []byte) func(*tagger) {
-	return func(o *tagger) {
+func WithAllocator(alloc func(int) []byte) func(*options) {
+	return func(o *options) {
 		o.alloc = alloc
 	}
 }
+
+// WithPool allocates the tokens that the tagger creates, like array indexes, from the pool,
+// so that they are accounted for by the pool, see pool.Stats and pool.Debug.
+func WithPool(pool pool.Pool) func(*options) {
+	return func(o *options) {
+		o.alloc = pool.Alloc
+	}
+}
@@ -52,4 +52,10 @@ const arrayTagIndexState = stateKind('I')
 
 const arrayTagElemState = stateKind('E')
 
+const valueTagOpenState = stateKind('<')
+
+const valueTagKeyState = stateKind('V')
+
+const valueTagValueState = stateKind('>')
+
 const endState = stateKind('$')
@@ -15,6 +15,7 @@
 package tag
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
@@ -34,42 +35,46 @@ type JSONSchemaAbleParser interface {
 	Reset()
 }
 
-type tagger struct {
-	p     JSONSchemaAbleParser
-	tag   bool
-	index bool
-	alloc func(size int) []byte
+type tagger[P JSONSchemaAbleParser] struct {
+	p P
+	options
 	// state
-	state state
-	stack []state
+	state  state
+	stack  []state
+	tokens tokens
+	// valueType is the tag of the value that is currently tagged, see WithValueTypes.
+	valueType []byte
+	// err is the error in the options, which is returned by Next and Skip.
+	err error
 }
 
-var objectTagToken = []byte("object")
-var arrayTagToken = []byte("array")
-
-// NewTagger can tag objects and arrays.
+// NewTagger can tag objects, arrays and values, see WithTags and WithValueTypes.
 // The following json: `{"a": []}`
 // is parsed as: `{"object": {"a": {"array": []}}}`.
 // The kind returned from the Token method for
 // "object" and "array" will be parse.TagKind.
+// The labels of the tags can be changed with WithLabels and WithPrefix.
 func NewTagger(p JSONSchemaAbleParser, opts ...Option) Parser {
-	t := &tagger{
-		p:     p,
-		tag:   false,
-		index: false,
-		alloc: func(size int) []byte {
-			return make([]byte, size)
-		},
-		state: state{},
-		stack: make([]state, 0, 10),
-	}
-	for _, opt := range opts {
-		opt(t)
+	return NewTaggerOf(p, opts...)
+}
+
+// NewTaggerOf is the same as NewTagger, but is generic over the type of the tagged parser.
+// When P is a concrete type, the compiler can inline calls to the tagged parser,
+// instead of calling it through an interface.
+func NewTaggerOf[P JSONSchemaAbleParser](p P, opts ...Option) Parser {
+	o := newOptions(opts...)
+	t := &tagger[P]{
+		p:       p,
+		options: o,
+		tokens:  newTokens(&o),
+		state:   state{},
+		stack:   make([]state, 0, 10),
 	}
+	t.err = t.tokens.distinct()
 	return t
 }
 
-func (t *tagger) Reset() {
+func (t *tagger[P]) Reset() {
 	// Reset the state.
 	t.state = state{}
 	// Shrink the stack's length, but keep it's capacity,
@@ -79,7 +84,19 @@ func (t *tagger) Reset() {
 	t.p.Reset()
 }
 
-func (t *tagger) nextStart(h parse.Hint) (parse.Hint, error) {
+func (t *tagger[P]) nextStart(h parse.Hint) (parse.Hint, error) {
+	if h == parse.ValueHint && t.valueTypes {
+		kind, _, err := t.p.Token()
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		t.valueType, err = t.tokens.valueTypeToken(kind)
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		t.down(valueTagOpenState)
+		return parse.EnterHint, nil
+	}
 	if t.tag {
 		switch h {
 		case parse.EnterHint:
@@ -93,7 +110,7 @@ func (t *tagger) nextStart(h parse.Hint) (parse.Hint, error) {
 			}
 			return parse.UnknownHint, errUnknownJSONSchemaType
 		case parse.LeaveHint:
-			if err := t.up(); err != nil {
+			if err := t.leave(); err != nil {
 				return parse.UnknownHint, err
 			}
 			return parse.LeaveHint, nil
@@ -111,7 +128,7 @@ func (t *tagger) nextStart(h parse.Hint) (parse.Hint, error) {
 			}
 			return parse.UnknownHint, errUnknownJSONSchemaType
 		case parse.LeaveHint:
-			if err := t.up(); err != nil {
+			if err := t.leave(); err != nil {
 				return parse.UnknownHint, err
 			}
 			return parse.LeaveHint, nil
@@ -129,7 +146,7 @@ func (t *tagger) nextStart(h parse.Hint) (parse.Hint, error) {
 			}
 			return parse.UnknownHint, errUnknownJSONSchemaType
 		case parse.LeaveHint:
-			if err := t.up(); err != nil {
+			if err := t.leave(); err != nil {
 				return parse.UnknownHint, err
 			}
 			return parse.LeaveHint, nil
@@ -138,7 +155,10 @@ func (t *tagger) nextStart(h parse.Hint) (parse.Hint, error) {
 	return h, nil
 }
 
-func (t *tagger) Next() (parse.Hint, error) {
+func (t *tagger[P]) Next() (parse.Hint, error) {
+	if t.err != nil {
+		return parse.UnknownHint, t.err
+	}
 	switch t.state.kind {
 	case startState:
 		h, err := t.p.Next()
@@ -147,6 +167,11 @@ func (t *tagger) Next() (parse.Hint, error) {
 		}
 		// helps to skip over object values
 		t.state.hint = h
+		if h == parse.FieldHint && t.strict {
+			if err := t.checkCollision(); err != nil {
+				return parse.UnknownHint, err
+			}
+		}
 		return t.nextStart(h)
 	case objectTagOpenState:
 		t.state.kind = objectTagKeyOpenState
@@ -156,12 +181,12 @@ func (t *tagger) Next() (parse.Hint, error) {
 		t.down(startState)
 		return parse.EnterHint, nil
 	case objectTagKeyCloseState:
-		if err := t.up(); err != nil {
+		if err := t.leave(); err != nil {
 			return parse.UnknownHint, err
 		}
 		return parse.LeaveHint, nil
 	case objectTagCloseState:
-		if err := t.up(); err != nil {
+		if err := t.leave(); err != nil {
 			return parse.UnknownHint, err
 		}
 		return parse.LeaveHint, nil
@@ -177,7 +202,7 @@ func (t *tagger) Next() (parse.Hint, error) {
 		}
 		return parse.EnterHint, nil
 	case arrayTagKeyCloseState:
-		if err := t.up(); err != nil {
+		if err := t.leave(); err != nil {
 			return parse.UnknownHint, err
 		}
 		return parse.LeaveHint, nil
@@ -188,7 +213,7 @@ func (t *tagger) Next() (parse.Hint, error) {
 		}
 		t.state.hint = h
 		if t.state.hint == parse.LeaveHint {
-			if err := t.up(); err != nil {
+			if err := t.leave(); err != nil {
 				return parse.UnknownHint, err
 			}
 			return parse.LeaveHint, nil
@@ -200,16 +225,30 @@ func (t *tagger) Next() (parse.Hint, error) {
 		t.state.kind = arrayTagIndexState
 		h := t.state.hint
 		return t.nextStart(h)
+	case valueTagOpenState:
+		t.state.kind = valueTagKeyState
+		return parse.FieldHint, nil
+	case valueTagKeyState:
+		t.state.kind = valueTagValueState
+		return parse.ValueHint, nil
+	case valueTagValueState:
+		if err := t.leave(); err != nil {
+			return parse.UnknownHint, err
+		}
+		return parse.LeaveHint, nil
 	case endState:
 		return parse.UnknownHint, io.EOF
 	}
 	panic(fmt.Sprintf("unreachable: unknown state = %v", t.state))
 }
 
-func (t *tagger) Skip() error {
+func (t *tagger[P]) Skip() error {
+	if t.err != nil {
+		return t.err
+	}
 	switch t.state.kind {
 	case startState:
-		if len(t.stack) == 0 {
+		if len(t.stack) == 0 || t.state.hint == parse.LeaveHint {
 			_, err := t.Next()
 			return err
 		}
@@ -227,8 +266,9 @@ func (t *tagger) Skip() error {
 		}
 		return t.p.Skip()
 	case objectTagKeyOpenState:
-		t.state.kind = arrayTagKeyCloseState
-		return nil
+		// Skip the tag's value, which is the whole object of the tagged parser.
+		t.state.kind = objectTagKeyCloseState
+		return t.p.Skip()
 	case objectTagKeyCloseState:
 		_, err := t.Next()
 		return err
@@ -238,12 +278,17 @@ func (t *tagger) Skip() error {
 		}
 		return t.p.Skip()
 	case arrayTagKeyOpenState:
+		// Skip the tag's value, which is the whole array of the tagged parser.
 		t.state.kind = arrayTagKeyCloseState
-		return nil
+		return t.p.Skip()
 	case arrayTagKeyCloseState:
 		_, err := t.Next()
 		return err
 	case arrayTagIndexState:
+		if t.state.hint == parse.LeaveHint {
+			_, err := t.Next()
+			return err
+		}
 		if err := t.up(); err != nil {
 			return err
 		}
@@ -255,33 +300,108 @@ func (t *tagger) Skip() error {
 			return nil
 		}
 		return t.p.Skip()
+	case valueTagOpenState:
+		// The tagged value was already read, so only the tag needs to be skipped.
+		return t.leave()
+	case valueTagKeyState:
+		t.state.kind = valueTagValueState
+		return nil
+	case valueTagValueState:
+		return t.leave()
 	case endState:
 		return t.p.Skip()
 	}
 	panic(fmt.Sprintf("unreachable: unknown state = %v", t.state))
 }
 
-func (t *tagger) Token() (parse.Kind, []byte, error) {
+func (t *tagger[P]) Token() (parse.Kind, []byte, error) {
 	switch t.state.kind {
 	case objectTagKeyOpenState:
-		return parse.TagKind, objectTagToken, nil
+		return parse.TagKind, t.tokens.object, nil
 	case arrayTagKeyOpenState:
-		return parse.TagKind, arrayTagToken, nil
+		return parse.TagKind, t.tokens.array, nil
 	case arrayTagElemState:
 		return parse.Int64Kind, cast.FromInt64(t.state.arrayIndex, t.alloc), nil
+	case valueTagKeyState:
+		return parse.TagKind, t.valueType, nil
+	case valueTagOpenState:
+		return parse.UnknownKind, nil, nil
+	}
+	if t.state.hint == parse.LeaveHint {
+		// The tagged parser's current token could be a value that was tagged.
+		return parse.UnknownKind, nil, nil
 	}
 	return t.p.Token()
 }
 
-func (t *tagger) down(stateKind stateKind) {
+// checkCollision returns a CollisionError if the current field name of the tagged parser collides with a label or the prefix.
+func (t *tagger[P]) checkCollision() error {
+	kind, name, err := t.p.Token()
+	if err != nil {
+		return err
+	}
+	if kind != parse.StringKind && kind != parse.BytesKind {
+		return nil
+	}
+	if t.prefix != "" {
+		if bytes.HasPrefix(name, []byte(t.prefix)) {
+			return &CollisionError{Name: string(name), Prefix: t.prefix}
+		}
+		return nil
+	}
+	for _, label := range t.tokens.reserved {
+		if bytes.Equal(name, label) {
+			return &CollisionError{Name: string(name)}
+		}
+	}
+	return nil
+}
+
+// Len returns the number of fields or elements of the object or array that was just entered, see parse.LenAble.
+// The object that wraps a tag or a value type always has one field, the tag,
+// otherwise the length of the tagged parser's object or array is returned.
+func (t *tagger[P]) Len() (int, bool) {
+	switch t.state.kind {
+	case objectTagOpenState, arrayTagOpenState, valueTagOpenState:
+		return 1, true
+	}
+	return parse.GetLen(t.p)
+}
+
+// Offset returns the offset of the current token of the tagged parser.
+// Tags have the offset of the object or array that they tag.
+func (t *tagger[P]) Offset() int64 {
+	offset, _ := parse.GetOffset(t.p)
+	return offset
+}
+
+// Span returns the span of the current token of the tagged parser.
+// Tags have the span of the object or array that they tag and
+// indexes have the span of the array item that they index.
+func (t *tagger[P]) Span() (int64, int64) {
+	start, end, _ := parse.GetSpan(t.p)
+	return start, end
+}
+
+func (t *tagger[P]) down(stateKind stateKind) {
 	// Append the current state to the stack.
 	t.stack = append(t.stack, t.state)
 	// Create a new state.
 	t.state.kind = stateKind
+	t.state.hint = parse.UnknownHint
 	t.state.arrayIndex = -1
 }
 
-func (t *tagger) up() error {
+// leave goes up and remembers that a LeaveHint was returned, so that Skip behaves like Next.
+func (t *tagger[P]) leave() error {
+	if err := t.up(); err != nil {
+		return err
+	}
+	t.state.hint = parse.LeaveHint
+	return nil
+}
+
+func (t *tagger[P]) up() error {
 	if len(t.stack) == 0 {
 		return errUnexpectedClose
 	}
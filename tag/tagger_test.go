@@ -0,0 +1,371 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package tag
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"katydid.org.za/go/parser-go/cast"
+	"katydid.org.za/go/parser-go/expect"
+	"katydid.org.za/go/parser-go/internal/replaytest"
+	"katydid.org.za/go/parser-go/jsonschema"
+	"katydid.org.za/go/parser-go/parse"
+	"katydid.org.za/go/parser-go/pool"
+	"katydid.org.za/go/parser-go/replay"
+)
+
+// newParser returns a parser for `{"a": [1, {}], "b": "c"}`.
+func newParser(t *testing.T) replay.Parser {
+	t.Helper()
+	return replaytest.NewParser(t, func(enc *replaytest.Encoder) {
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Field(parse.StringKind, []byte("a"))
+		enc.Enter(jsonschema.JSONSchemaTypeArray)
+		enc.Value(parse.Int64Kind, replaytest.Int64(1))
+		enc.Enter(jsonschema.JSONSchemaTypeObject)
+		enc.Leave()
+		enc.Leave()
+		enc.Field(parse.StringKind, []byte("b"))
+		enc.Value(parse.StringKind, []byte("c"))
+		enc.Leave()
+	})
+}
+
+func TestSpan(t *testing.T) {
+	p := NewTagger(newParser(t), WithTags(), WithIndexes())
+	prev := int64(0)
+	for {
+		hint, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		start, end, ok := parse.GetSpan(p)
+		if !ok {
+			t.Fatalf("expected span for %v", hint)
+		}
+		if start < prev || end < start {
+			t.Fatalf("span [%d:%d] of %v is not monotonic, previous start %d", start, end, hint, prev)
+		}
+		prev = start
+	}
+}
+
+type noSpan struct {
+	replay.Parser
+}
+
+func TestNoSpan(t *testing.T) {
+	p := NewTagger(noSpan{newParser(t)}, WithTags())
+	expect.Hint(t, p, parse.EnterHint)
+	if _, _, ok := parse.GetSpan(p); ok {
+		t.Fatal("expected no span, since the tagged parser does not report spans")
+	}
+}
+
+func TestLen(t *testing.T) {
+	p := NewTagger(newParser(t), WithTags())
+	expectLen := func(want int) {
+		t.Helper()
+		got, ok := parse.GetLen(p)
+		if !ok || got != want {
+			t.Fatalf("want length %d, but got %d, %v", want, got, ok)
+		}
+	}
+	// {"object": {"a": {"array": [1, {"object": {}}]}, "b": "c"}}
+	expect.Hint(t, p, parse.EnterHint)
+	expectLen(1)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "object")
+	expect.Hint(t, p, parse.EnterHint)
+	expectLen(2)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.EnterHint)
+	expectLen(1)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "array")
+	expect.Hint(t, p, parse.EnterHint)
+	expectLen(2)
+}
+
+func TestWithPool(t *testing.T) {
+	d := pool.NewDebug(pool.New())
+	p := NewTagger(newParser(t), WithTags(), WithIndexes(), WithPool(d))
+	for {
+		if _, err := p.Next(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatal(err)
+		}
+		if _, _, err := p.Token(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The array has two items, which are each indexed.
+	if len(d.Busy()) != 2 {
+		t.Fatalf("want the indexes to be allocated from the pool, but got %d allocations", len(d.Busy()))
+	}
+	if stats := d.Stats(); stats.InUse == 0 {
+		t.Fatalf("want bytes in use, but got %+v", stats)
+	}
+	d.FreeAll()
+	if err := d.Check(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPoisonedIndex(t *testing.T) {
+	pl := pool.New(pool.WithPoison())
+	p := NewTagger(newParser(t), WithTags(), WithIndexes(), WithPool(pl))
+	var index []byte
+	for index == nil {
+		if _, err := p.Next(); err != nil {
+			t.Fatal(err)
+		}
+		kind, value, err := p.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind == parse.Int64Kind {
+			index = value
+		}
+	}
+	if got := cast.ToInt64(index); got != 0 {
+		t.Fatalf("want index 0, but got %d", got)
+	}
+	pl.FreeAll()
+	// A consumer that holds on to the index past FreeAll reads poison.
+	if index[0] != pool.Poison {
+		t.Fatalf("want the index to be allocated from the pool and poisoned, but got %v", index)
+	}
+}
+
+type token struct {
+	hint  parse.Hint
+	kind  parse.Kind
+	value string
+}
+
+// walk calls Next until EOF, but calls Skip instead of Next at the given step, and returns the tokens.
+func walk(t *testing.T, p parse.Parser, skip int) []token {
+	t.Helper()
+	var tokens []token
+	for step := 0; ; step++ {
+		if step == skip {
+			if err := p.Skip(); err != nil {
+				t.Fatalf("skip at step %d: %v", step, err)
+			}
+			tokens = append(tokens, token{hint: parse.UnknownHint, value: "skip"})
+			continue
+		}
+		hint, err := p.Next()
+		if err == io.EOF {
+			return tokens
+		}
+		if err != nil {
+			t.Fatalf("next at step %d: %v", step, err)
+		}
+		kind, value, err := p.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tokens = append(tokens, token{hint, kind, string(value)})
+	}
+}
+
+// TestSkip checks the tagger's state machine, by comparing skipping at every step,
+// to skipping through a recording of the tagged tokens.
+func TestSkip(t *testing.T) {
+	options := map[string][]Option{
+		"none":                nil,
+		"tags":                {WithTags()},
+		"tags indexes":        {WithTags(), WithIndexes()},
+		"value types":         {WithValueTypes()},
+		"tags value types":    {WithTags(), WithValueTypes()},
+		"indexes value types": {WithTags(), WithIndexes(), WithValueTypes()},
+	}
+	for name, opts := range options {
+		t.Run(name, func(t *testing.T) {
+			recorded, err := replay.Record(NewTagger(newParser(t), opts...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := replay.NewParser()
+			want.Init(recorded)
+			steps := len(walk(t, want, -1))
+			for skip := 0; skip < steps; skip++ {
+				want.Init(recorded)
+				wantTokens := walk(t, want, skip)
+				gotTokens := walk(t, NewTagger(newParser(t), opts...), skip)
+				if len(gotTokens) != len(wantTokens) {
+					t.Fatalf("skip at step %d: want %v, but got %v", skip, wantTokens, gotTokens)
+				}
+				for i := range wantTokens {
+					if gotTokens[i] != wantTokens[i] {
+						t.Fatalf("skip at step %d: want %v, but got %v", skip, wantTokens, gotTokens)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestValueTypes(t *testing.T) {
+	p := NewTagger(newParser(t), WithTags(), WithIndexes(), WithValueTypes())
+	// {"object": {"a": {"array": {0: {"integer": 1}, 1: {"object": {}}}}, "b": {"string": "c"}}}
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "object")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "array")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Int(t, p, 0)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "integer")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "object")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "string")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "c")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestValueTypeUnknownKind(t *testing.T) {
+	r := replaytest.NewParser(t, func(enc *replaytest.Encoder) {
+		enc.Value(parse.Kind('?'), nil)
+	})
+	p := NewTagger(r, WithValueTypes())
+	if _, err := p.Next(); err == nil {
+		t.Fatal("want an error for a value without a JSON Schema type")
+	}
+}
+
+func TestLabels(t *testing.T) {
+	opts := []Option{WithTags(), WithValueTypes(), WithLabels(Labels{Object: "obj", Integer: "int"}), WithPrefix("@")}
+	labels := LabelsOf(opts...)
+	if labels.Object != "@obj" || labels.Array != "@array" || labels.Integer != "@int" || labels.String != "@string" {
+		t.Fatalf("want prefixed labels with defaults, but got %+v", labels)
+	}
+	p := NewTagger(newParser(t), opts...)
+	// {"@obj": {"a": {"@array": [{"@int": 1}, ...
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, labels.Object)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, labels.Array)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, labels.Integer)
+}
+
+func TestDuplicateLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		duplicate bool
+	}{
+		{"object and array", []Option{WithTags(), WithLabels(Labels{Object: "x", Array: "x"})}, true},
+		{"default label", []Option{WithTags(), WithLabels(Labels{Object: "array"})}, true},
+		{"value types", []Option{WithValueTypes(), WithLabels(Labels{Integer: "number"}), WithPrefix("@")}, true},
+		{"unused label", []Option{WithTags(), WithLabels(Labels{Object: "string"})}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := NewTagger(newParser(t), test.opts...)
+			var err error
+			for err == nil {
+				_, err = p.Next()
+			}
+			var duplicate *DuplicateLabelError
+			if errors.As(err, &duplicate) != test.duplicate {
+				t.Fatalf("want duplicate %v, but got %v", test.duplicate, err)
+			}
+			if !test.duplicate && err != io.EOF {
+				t.Fatal(err)
+			}
+			if test.duplicate {
+				if err := p.Skip(); !errors.As(err, &duplicate) {
+					t.Fatalf("want Skip to return the duplicate, but got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		collide bool
+	}{
+		{"default labels", []Option{WithTags(), WithStrict()}, false},
+		{"object label", []Option{WithTags(), WithStrict(), WithLabels(Labels{Object: "a"})}, true},
+		{"unused label", []Option{WithTags(), WithStrict(), WithLabels(Labels{String: "a"})}, false},
+		{"value type label", []Option{WithValueTypes(), WithStrict(), WithLabels(Labels{String: "b"})}, true},
+		{"prefix", []Option{WithTags(), WithStrict(), WithPrefix("b")}, true},
+		{"not strict", []Option{WithTags(), WithLabels(Labels{Object: "a"})}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := NewTagger(newParser(t), test.opts...)
+			var err error
+			for err == nil {
+				_, err = p.Next()
+			}
+			var collision *CollisionError
+			if errors.As(err, &collision) != test.collide {
+				t.Fatalf("want collision %v, but got %v", test.collide, err)
+			}
+			if !test.collide && err != io.EOF {
+				t.Fatal(err)
+			}
+		})
+	}
+}